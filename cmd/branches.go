@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/analysis"
+	"github.com/k1-end/git-reports/src/pathfilter"
+	"github.com/k1-end/git-reports/src/progress"
+	"github.com/k1-end/git-reports/src/reportgenerator"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+var sideReports bool
+
+// branchesOnlyReports can not be scoped to the commits of one side.
+var branchesOnlyReports = []string{"general-info", "file-types", "lines-of-code"}
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <refA> <refB> [options]",
+	Short: "Compare two branches",
+	Long:  "Report how far two branches, tags or commits have drifted apart since their merge base: the commits only found on each side, their developers and the files changed on both sides. With --side-reports, the reports given with --reports are added for the commits of each side.",
+	Args:  cobra.ExactArgs(2),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setTimezone(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if err := checkGeneratorOptions(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		commitPeriod, err := parsePeriod(fromDate, toDate)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if outputPath != "" && outputPath != "-" && !isValidFilePath(outputPath) {
+			fmt.Fprintln(os.Stderr, "The given output is not a valid file path or is not writable")
+			os.Exit(1)
+		}
+		pathFilter, err := pathfilter.NewPathFilter(includePaths, excludePaths)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		mailmapAuthors, err := ParseMailmapCommitEmailsAndName(path)
+		checkIfError(err)
+		authors := reportgenerator.NewAuthors(mailmapAuthors)
+
+		r, err := git.PlainOpen(path)
+		if errors.Is(err, git.ErrRepositoryNotExists) {
+			fmt.Fprintln(os.Stderr, "The provided path is not a git repository: " + path)
+			os.Exit(1)
+		}
+		checkIfError(err)
+
+		var tips [2]*object.Commit
+		for i, name := range args {
+			tips[i], err = resolveRef(r, name)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+
+		var sides [2]*generatorSet
+		for i := range sides {
+			sides[i], err = newGeneratorSet(authors)
+			checkIfError(err)
+		}
+
+		progressLine := progress.Start(os.Stderr)
+		ctx, cancel := analysisContext()
+		defer cancel()
+
+		var historyNote string
+		branchGenerator := reportgenerator.BranchComparisonReportGenerator{Refs: [2]string{args[0], args[1]}, Identity: identity}
+		mergeBases, err := tips[0].MergeBase(tips[1])
+		checkIfError(err)
+		if len(mergeBases) > 0 {
+			branchGenerator.MergeBase = mergeBases[0]
+			progressLine.SetPhase(progress.PhaseDiff)
+			for i, tip := range tips {
+				branchGenerator.FileStats[i], err = changesSince(ctx, mergeBases[0], tip, pathFilter)
+				if isInterruption(err) {
+					historyNote = incompleteNote(err, "0 commits")
+					break
+				}
+				checkIfError(err)
+			}
+			progressLine.SetPhase(progress.PhaseLog)
+		}
+
+		for i, tip := range tips {
+			if historyNote != "" {
+				break
+			}
+			side := branchGenerator.Side(i)
+			generators := sides[i]
+			logOptions := analysis.LogOptions{From: tip.Hash, Exclude: tips[1-i].Hash, Workers: workers}
+			logOptions.NeedStats = sideReports && len(generators.stats) > 0
+			if !pathFilter.IsEmpty() {
+				logOptions.PathFilter = pathFilter.Match
+			}
+			err = analysis.WalkLog(ctx, r, logOptions, func(c *object.Commit) (reportgenerator.Author, bool) {
+				progressLine.AddCommit()
+				signature := identity.Signature(c)
+				author := authors.Resolve(signature)
+				if !isSelectedAuthor(authors, author) || !commitPeriod.contains(signature.When) {
+					return reportgenerator.Author{}, false
+				}
+				return *author, true
+			}, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {
+				side.LogIterationStep(c, a)
+				if !sideReports {
+					return
+				}
+				for _, g := range generators.log {
+					g.LogIterationStep(c, a)
+				}
+				if stats == nil {
+					return // the commit could not be diffed, e.g. the first one of a shallow clone
+				}
+				for _, g := range generators.stats {
+					g.StatsIterationStep(c, a, stats)
+				}
+			})
+			if isInterruption(err) {
+				historyNote = incompleteNote(err, message.NewPrinter(language.English).Sprintf("%d commits", progressLine.Commits()))
+				break
+			}
+			checkIfError(err)
+		}
+
+		absolutePath, _ := filepath.Abs(path)
+
+		progressLine.SetPhase(progress.PhaseRendering)
+		p := getPrinter(printerOption)
+		for _, generatedReport := range branchGenerator.GetReports() {
+			generatedReport.SetNote(historyNote)
+			p.RegisterReport(generatedReport)
+		}
+		for i, name := range args {
+			if !sideReports {
+				break
+			}
+			for _, reportName := range reportNames {
+				if !slices.Contains(selectedReports, reportName) || slices.Contains(branchesOnlyReports, reportName) {
+					continue
+				}
+				for _, generatedReport := range getReports(sides[i].reports[reportName]) {
+					generatedReport.SetTitle(generatedReport.GetTitle() + " on " + name)
+					generatedReport.SetNote(joinNotes(historyNote, generatedReport.GetNote()))
+					p.RegisterReport(generatedReport)
+				}
+			}
+		}
+		p.SetProjectTitle(filepath.Base(absolutePath))
+
+		destination := os.Stdout
+		if outputPath != "" && outputPath != "-" {
+			destination, err = os.Create(outputPath)
+			if err != nil {
+				progressLine.Stop()
+				fmt.Println("os.Create:", err)
+				return
+			}
+			defer destination.Close()
+		}
+		progressLine.Stop()
+		p.Print(destination)
+
+		if historyNote != "" {
+			fmt.Fprintln(os.Stderr, "The analysis was stopped before the end, the reports are incomplete")
+			os.Exit(1)
+		}
+	},
+}
+
+// resolveRef returns the commit of a branch, a tag, a remote branch or any other revision.
+func resolveRef(r *git.Repository, name string) (*object.Commit, error) {
+	hash, err := r.ResolveRevision(plumbing.Revision(name))
+	if err != nil {
+		return nil, fmt.Errorf("Unknown revision '%s'", name)
+	}
+	return r.CommitObject(*hash)
+}
+
+// changesSince returns the changes of the files matching the path filter from the merge base to the tip.
+func changesSince(ctx context.Context, mergeBase *object.Commit, tip *object.Commit, pathFilter pathfilter.PathFilter) (object.FileStats, error) {
+	patch, err := mergeBase.PatchContext(ctx, tip)
+	if ctx.Err() != nil {
+		return nil, ctx.Err() // go-git reports its own cancellation error
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stats object.FileStats
+	for _, file := range patch.Stats() {
+		if pathFilter.Match(file.Name) {
+			stats = append(stats, file)
+		}
+	}
+	return stats, nil
+}
+
+func init() {
+	compareCmd.Flags().BoolVar(&sideReports, "side-reports", false, "Add the reports given with --reports for the commits of each side")
+	rootCmd.AddCommand(compareCmd)
+}
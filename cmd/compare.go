@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/k1-end/git-reports/src/report"
+	"github.com/k1-end/git-reports/src/reportgenerator"
+)
+
+// overTimeReports follow the history period by period, their periods can not be compared.
+var overTimeReports = []string{"heatmap", "merge-commits-per-year", "contributor-lifecycle", "active-contributors", "code-growth"}
+
+// period is a range of commit dates, a zero bound leaves the range open on that side.
+// Like --from and --to, both bounds are included.
+type period struct {
+	from time.Time
+	to   time.Time
+}
+
+func (p period) contains(t time.Time) bool {
+	return (p.from.IsZero() || !t.Before(p.from)) && (p.to.IsZero() || !t.After(p.to))
+}
+
+// overlaps reports whether the periods share more than a bound, an open bound overlaps everything on its side.
+func (p period) overlaps(other period) bool {
+	return (p.from.IsZero() || other.to.IsZero() || p.from.Before(other.to)) &&
+		(other.from.IsZero() || p.to.IsZero() || other.from.Before(p.to))
+}
+
+// parsePeriod returns the period given by the --from and --to dates, both may be empty.
+func parsePeriod(from string, to string) (p period, err error) {
+	if from != "" {
+		p.from, err = time.ParseInLocation(time.DateOnly, from, time.Local)
+		if err != nil {
+			return period{}, errors.New("Invalid 'from' date format. Please use YYYY-MM-DD.")
+		}
+	}
+	if to != "" {
+		p.to, err = time.ParseInLocation(time.DateOnly, to, time.Local)
+		if err != nil {
+			return period{}, errors.New("Invalid 'to' date format. Please use YYYY-MM-DD.")
+		}
+	}
+	if from != "" && to != "" && p.from.After(p.to) {
+		return period{}, errors.New("'from' date must be before 'to' date.")
+	}
+	return p, nil
+}
+
+// String names the period after its bounds, e.g. "2024-04-01 to 2024-05-01" or "until 2024-05-01".
+func (p period) String() string {
+	switch {
+	case p.from.IsZero() && p.to.IsZero():
+		return "whole history"
+	case p.from.IsZero():
+		return "until " + p.to.Format(time.DateOnly)
+	case p.to.IsZero():
+		return "since " + p.from.Format(time.DateOnly)
+	default:
+		return p.from.Format(time.DateOnly) + " to " + p.to.Format(time.DateOnly)
+	}
+}
+
+// comparedPeriod returns the period the current one is compared to: the one given by --compare-from
+// and --compare-to, or with --compare previous the period of the same length just before the current
+// one, ending today when --to is not given. The periods may only share a bound, a commit on it is
+// counted in the current period. ok is false when there is nothing to compare.
+func comparedPeriod(current period, compare string, compareFrom string, compareTo string, now time.Time) (previous period, ok bool, err error) {
+	switch compare {
+	case "":
+	case "previous":
+		if compareFrom != "" || compareTo != "" {
+			return period{}, false, errors.New("--compare previous can not be used with --compare-from and --compare-to")
+		}
+		if current.from.IsZero() {
+			return period{}, false, errors.New("--compare previous needs the --from date of the current period")
+		}
+		to := current.to
+		if to.IsZero() {
+			to = now
+		}
+		days := int(math.Round(to.Sub(current.from).Hours() / 24))
+		return period{from: current.from.AddDate(0, 0, -days), to: current.from}, true, nil
+	default:
+		return period{}, false, fmt.Errorf("invalid compare value '%s'. The only valid value is `previous`", compare)
+	}
+
+	if compareFrom == "" && compareTo == "" {
+		return period{}, false, nil
+	}
+	if compareFrom != "" {
+		previous.from, err = time.ParseInLocation(time.DateOnly, compareFrom, time.Local)
+		if err != nil {
+			return period{}, false, errors.New("invalid 'compare-from' date format. Please use YYYY-MM-DD")
+		}
+	}
+	if compareTo != "" {
+		previous.to, err = time.ParseInLocation(time.DateOnly, compareTo, time.Local)
+		if err != nil {
+			return period{}, false, errors.New("invalid 'compare-to' date format. Please use YYYY-MM-DD")
+		}
+	}
+	if compareFrom != "" && compareTo != "" && previous.from.After(previous.to) {
+		return period{}, false, errors.New("'compare-from' date must be before 'compare-to' date")
+	}
+	// A commit is only counted in one period, the overlapping commits would all go to the current one
+	if previous.overlaps(current) {
+		return period{}, false, fmt.Errorf("the compared period (%s) overlaps the current period (%s). Use --from and --to to set a current period before or after it", previous, current)
+	}
+	return previous, true, nil
+}
+
+// compareReports returns the reports of both periods side by side where they can be compared, and the
+// reports of the current period with a note otherwise.
+func compareReports(name string, previous *generatorSet, current *generatorSet, previousName string, currentName string) []report.Report {
+	if name == "general-info" {
+		return reportgenerator.CompareGeneralInfo(previous.generalInfo, current.generalInfo, previousName, currentName)
+	}
+
+	currentOnly := "Only the current period, " + currentName
+	var previousReports []report.Report
+	if !slices.Contains(overTimeReports, name) {
+		previousReports = getReports(previous.reports[name])
+	}
+	var reports []report.Report
+	for i, currentReport := range getReports(current.reports[name]) {
+		if i < len(previousReports) {
+			if compared, ok := report.Compare(previousReports[i], currentReport, previousName, currentName); ok {
+				reports = append(reports, compared)
+				continue
+			}
+		}
+		currentReport.SetNote(currentOnly)
+		reports = append(reports, currentReport)
+	}
+	return reports
+}
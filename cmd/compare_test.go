@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestPeriod(t *testing.T) {
+	may := period{from: date(2024, time.May, 1), to: date(2024, time.May, 31)}
+	assert.True(t, may.contains(date(2024, time.May, 1)), "The bounds should be included")
+	assert.True(t, may.contains(date(2024, time.May, 15)))
+	assert.False(t, may.contains(date(2024, time.April, 30)))
+	assert.False(t, may.contains(date(2024, time.June, 1)))
+	assert.True(t, period{}.contains(date(1999, time.January, 1)), "A period without bounds should contain everything")
+
+	assert.Equal(t, "2024-05-01 to 2024-05-31", may.String())
+	assert.Equal(t, "since 2024-05-01", period{from: may.from}.String())
+	assert.Equal(t, "until 2024-05-31", period{to: may.to}.String())
+	assert.Equal(t, "whole history", period{}.String())
+
+	assert.True(t, may.overlaps(period{from: date(2024, time.May, 20), to: date(2024, time.June, 20)}))
+	assert.True(t, may.overlaps(period{}), "A period without bounds should overlap everything")
+	assert.True(t, period{to: may.to}.overlaps(period{from: date(2020, time.January, 1), to: date(2030, time.January, 1)}))
+	assert.False(t, may.overlaps(period{from: date(2024, time.April, 1), to: may.from}), "Periods sharing a bound should not overlap")
+	assert.False(t, may.overlaps(period{from: date(2024, time.June, 1)}))
+}
+
+func TestParsePeriod(t *testing.T) {
+	p, err := parsePeriod("2024-05-01", "2024-05-31")
+	require.NoError(t, err)
+	assert.Equal(t, period{from: date(2024, time.May, 1), to: date(2024, time.May, 31)}, p)
+
+	p, err = parsePeriod("", "")
+	require.NoError(t, err)
+	assert.Equal(t, period{}, p)
+
+	_, err = parsePeriod("05/01/2024", "")
+	assert.EqualError(t, err, "Invalid 'from' date format. Please use YYYY-MM-DD.")
+	_, err = parsePeriod("2024-05-31", "2024-05-01")
+	assert.EqualError(t, err, "'from' date must be before 'to' date.")
+}
+
+func TestComparedPeriod(t *testing.T) {
+	current := period{from: date(2024, time.May, 1), to: date(2024, time.May, 31)}
+	now := date(2024, time.June, 10)
+
+	_, ok, err := comparedPeriod(current, "", "", "", now)
+	require.NoError(t, err)
+	assert.False(t, ok, "Nothing should be compared without the flags")
+
+	previous, ok, err := comparedPeriod(current, "previous", "", "", now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, period{from: date(2024, time.April, 1), to: date(2024, time.May, 1)}, previous, "The previous period should be as long as the current one")
+
+	previous, _, err = comparedPeriod(period{from: date(2024, time.June, 1)}, "previous", "", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, period{from: date(2024, time.May, 23), to: date(2024, time.June, 1)}, previous, "The current period should end today without --to")
+
+	previous, ok, err = comparedPeriod(current, "", "2024-04-01", "2024-04-30", now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, period{from: date(2024, time.April, 1), to: date(2024, time.April, 30)}, previous)
+
+	previous, ok, err = comparedPeriod(current, "", "", "2023-12-31", now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, period{to: date(2023, time.December, 31)}, previous, "The compared period may be open")
+
+	for _, tc := range []struct {
+		compare     string
+		compareFrom string
+		compareTo   string
+		current     period
+		description string
+	}{
+		{"last", "", "", current, "Invalid compare value"},
+		{"previous", "2024-04-01", "", current, "Both ways of giving the period"},
+		{"previous", "", "", period{to: current.to}, "Previous period without a start"},
+		{"", "04/01/2024", "", current, "Invalid date"},
+		{"", "2024-04-30", "2024-04-01", current, "Dates in the wrong order"},
+		{"", "2020-01-01", "2030-01-01", period{}, "Overlap with the whole history"},
+		{"", "2024-05-15", "2024-06-15", current, "Overlapping periods"},
+		{"", "2024-01-01", "", current, "Compared period without an end"},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			_, _, err := comparedPeriod(tc.current, tc.compare, tc.compareFrom, tc.compareTo, now)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+const configFileName = ".git-reports.yaml"
+
+// configFilePaths returns the config files to read, the most specific one first:
+// the one at the root of the analyzed repository, then the one in the user config directory.
+func configFilePaths(repoPath string) []string {
+    paths := []string{filepath.Join(repoPath, configFileName)}
+    if configDir, err := os.UserConfigDir(); err == nil {
+        paths = append(paths, filepath.Join(configDir, configFileName))
+    }
+    return paths
+}
+
+// loadConfig applies every config file returned by configFilePaths to the flags of the command.
+// Flags given on the command line always win, then the repository config, then the user config.
+func loadConfig(cmd *cobra.Command, repoPath string) error {
+    var otherFlags []*pflag.FlagSet
+    for _, other := range append(cmd.Root().Commands(), cmd.Root()) {
+        if other != cmd {
+            otherFlags = append(otherFlags, other.Flags())
+        }
+    }
+    for _, configPath := range configFilePaths(repoPath) {
+        if err := applyConfigFile(cmd.Flags(), otherFlags, configPath); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// applyConfigFile sets every flag that has not been set yet to the value found in the config file.
+// Keys of the file are the long names of the flags. The keys of the otherFlags only, the options of
+// the other commands, are skipped so that one file serves every command. A missing file is not an error.
+func applyConfigFile(flags *pflag.FlagSet, otherFlags []*pflag.FlagSet, configPath string) error {
+    content, err := os.ReadFile(configPath)
+    if errors.Is(err, fs.ErrNotExist) {
+        return nil // the config file is not required
+    }
+    if err != nil {
+        return fmt.Errorf("can not read config file %s: %w", configPath, err)
+    }
+
+    values := make(map[string]yaml.Node)
+    if err := yaml.Unmarshal(content, &values); err != nil {
+        return fmt.Errorf("invalid config file %s: %w", configPath, err)
+    }
+
+    keys := make([]string, 0, len(values))
+    for k := range values {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+
+    for _, key := range keys {
+        flag := flags.Lookup(key)
+        if flag == nil && key != "version" && slices.ContainsFunc(otherFlags, func(other *pflag.FlagSet) bool { return other.Lookup(key) != nil }) {
+            continue
+        }
+        if flag == nil || key == "version" {
+            return fmt.Errorf("invalid config file %s: unknown option %q", configPath, key)
+        }
+        if flag.Changed {
+            continue
+        }
+        node := values[key]
+        if err := setFlagFromNode(flags, flag, &node); err != nil {
+            return fmt.Errorf("invalid config file %s: option %q: %w", configPath, key, err)
+        }
+    }
+    return nil
+}
+
+func setFlagFromNode(flags *pflag.FlagSet, flag *pflag.Flag, node *yaml.Node) error {
+    sliceValue, isSlice := flag.Value.(pflag.SliceValue)
+
+    switch node.Kind {
+    case yaml.ScalarNode:
+        if isSlice {
+            if err := sliceValue.Replace([]string{node.Value}); err != nil {
+                return err
+            }
+            flag.Changed = true
+            return nil
+        }
+        return flags.Set(flag.Name, node.Value)
+    case yaml.SequenceNode:
+        if !isSlice {
+            return fmt.Errorf("expects a single value, got a list on line %d", node.Line)
+        }
+        items := make([]string, 0, len(node.Content))
+        for _, item := range node.Content {
+            if item.Kind != yaml.ScalarNode {
+                return fmt.Errorf("list items must be plain values on line %d", item.Line)
+            }
+            items = append(items, item.Value)
+        }
+        if err := sliceValue.Replace(items); err != nil {
+            return err
+        }
+        flag.Changed = true
+        return nil
+    default:
+        return fmt.Errorf("unsupported value on line %d", node.Line)
+    }
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFlagSet() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("printer", "console", "")
+	flags.String("from", "", "")
+	flags.StringSlice("reports", []string{"a", "b"}, "")
+	flags.Bool("version", false, "")
+	return flags
+}
+
+func TestApplyConfigFile(t *testing.T) {
+	t.Run("Config values are used as defaults", func(t *testing.T) {
+		content := "printer: html\nfrom: 2023-01-01\nreports: [b]\n"
+		_, configPath, cleanup := createTempDirAndFile(t, "test_config", configFileName, content)
+		defer cleanup()
+
+		flags := newTestFlagSet()
+		require.NoError(t, applyConfigFile(flags, nil, configPath))
+
+		printer, _ := flags.GetString("printer")
+		from, _ := flags.GetString("from")
+		reports, _ := flags.GetStringSlice("reports")
+		assert.Equal(t, "html", printer, "Printer should be read from the config file")
+		assert.Equal(t, "2023-01-01", from, "Dates should be kept as written in the config file")
+		assert.Equal(t, []string{"b"}, reports, "Lists should replace the default value")
+	})
+
+	t.Run("Command line flags override the config file", func(t *testing.T) {
+		_, configPath, cleanup := createTempDirAndFile(t, "test_config", configFileName, "printer: html\n")
+		defer cleanup()
+
+		flags := newTestFlagSet()
+		require.NoError(t, flags.Parse([]string{"--printer", "console"}))
+		require.NoError(t, applyConfigFile(flags, nil, configPath))
+
+		printer, _ := flags.GetString("printer")
+		assert.Equal(t, "console", printer, "The command line value should win")
+	})
+
+	t.Run("Earlier config files win over later ones", func(t *testing.T) {
+		_, repoConfig, cleanup := createTempDirAndFile(t, "test_config", configFileName, "printer: html\n")
+		defer cleanup()
+		_, userConfig, cleanupUser := createTempDirAndFile(t, "test_config", "user.yaml", "printer: console\nfrom: 2020-01-01\n")
+		defer cleanupUser()
+
+		flags := newTestFlagSet()
+		require.NoError(t, applyConfigFile(flags, nil, repoConfig))
+		require.NoError(t, applyConfigFile(flags, nil, userConfig))
+
+		printer, _ := flags.GetString("printer")
+		from, _ := flags.GetString("from")
+		assert.Equal(t, "html", printer, "The repository config should win over the user config")
+		assert.Equal(t, "2020-01-01", from, "The user config should fill the remaining options")
+	})
+
+	t.Run("Options of the other commands are skipped", func(t *testing.T) {
+		_, configPath, cleanup := createTempDirAndFile(t, "test_config", configFileName, "printer: html\nside-reports: true\n")
+		defer cleanup()
+		compareFlags := pflag.NewFlagSet("compare", pflag.ContinueOnError)
+		compareFlags.Bool("side-reports", false, "")
+
+		flags := newTestFlagSet()
+		require.NoError(t, applyConfigFile(flags, []*pflag.FlagSet{compareFlags}, configPath), "An option of another command is not unknown")
+		printer, _ := flags.GetString("printer")
+		assert.Equal(t, "html", printer)
+		assert.False(t, compareFlags.Lookup("side-reports").Changed, "The options of the other commands should be left alone")
+
+		compareFlags.String("printer", "console", "")
+		require.NoError(t, applyConfigFile(compareFlags, []*pflag.FlagSet{newTestFlagSet()}, configPath))
+		sideReports, _ := compareFlags.GetBool("side-reports")
+		assert.True(t, sideReports, "The options of the running command should be set")
+	})
+
+	t.Run("Missing config file", func(t *testing.T) {
+		flags := newTestFlagSet()
+		assert.NoError(t, applyConfigFile(flags, nil, "/nonexistent/"+configFileName), "A missing config file is not an error")
+	})
+
+	t.Run("Invalid config files", func(t *testing.T) {
+		testCases := []struct {
+			content     string
+			expectedErr string
+			description string
+		}{
+			{"printer: [html", "invalid config file", "Malformed YAML"},
+			{"colour: red\n", `unknown option "colour"`, "Unknown option"},
+			{"version: true\n", `unknown option "version"`, "Version is not an option"},
+			{"printer: [html, console]\n", "expects a single value", "List for a single value option"},
+			{"reports:\n  a: b\n", "unsupported value", "Map value"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				_, configPath, cleanup := createTempDirAndFile(t, "test_config", configFileName, tc.content)
+				defer cleanup()
+
+				err := applyConfigFile(newTestFlagSet(), nil, configPath)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), configPath, "Error should name the config file")
+				assert.Contains(t, err.Error(), tc.expectedErr)
+			})
+		}
+	})
+}
+
+func TestLoadConfig_SubcommandOption(t *testing.T) {
+	dir, _, cleanup := createTempDirAndFile(t, "test_config", configFileName, "side-reports: true\n")
+	defer cleanup()
+	defer func() {
+		sideReports = false
+		compareCmd.Flags().Lookup("side-reports").Changed = false
+	}()
+
+	require.NoError(t, loadConfig(rootCmd, dir), "The options of compare should not break the root command")
+	assert.False(t, sideReports)
+	require.NoError(t, loadConfig(compareCmd, dir))
+	assert.True(t, sideReports, "compare should read its own options")
+}
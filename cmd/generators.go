@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/k1-end/git-reports/src/linguist"
+	"github.com/k1-end/git-reports/src/report"
+	"github.com/k1-end/git-reports/src/reportgenerator"
+)
+
+// generatorSet holds the generators of the reports, the comparison mode needs one set per period.
+type generatorSet struct {
+	generalInfo *reportgenerator.GeneralInfoReportGenerator
+	fileType    *reportgenerator.FileTypeReportGenerator
+	linesOfCode *reportgenerator.LinesOfCodeReportGenerator
+	log         []reportgenerator.LogIterationStepper
+	stats       []reportgenerator.StatsIterationStepper // only the ones of the selected reports
+	reports     map[string]reportgenerator.ReportGenerator
+}
+
+// checkGeneratorOptions validates the options of the command line used by the generators.
+func checkGeneratorOptions() error {
+	for _, name := range selectedReports {
+		if !slices.Contains(reportNames, name) {
+			return fmt.Errorf("Invalid report '%s'. Valid values are %s", name, strings.Join(reportNames, ", "))
+		}
+	}
+	if fileTypesBy != "language" && fileTypesBy != "extension" {
+		return errors.New("Invalid file-types-by value. Valid values are `language` and `extension`")
+	}
+	if granularity != "" && !slices.Contains(reportgenerator.Granularities, granularity) {
+		return errors.New("Invalid granularity value. Valid values are `day`, `week`, `month`, `quarter` and `year`")
+	}
+	if inactiveDays <= 0 {
+		return errors.New("Invalid inactive-days value. Please use a positive number of days.")
+	}
+	if identity != reportgenerator.IdentityAuthor && identity != reportgenerator.IdentityCommitter {
+		return errors.New("Invalid identity value. Valid values are `author` and `committer`")
+	}
+	if !slices.Contains([]reportgenerator.CoAuthorCredit{reportgenerator.CoAuthorCreditAuthorOnly, reportgenerator.CoAuthorCreditFull, reportgenerator.CoAuthorCreditFractional}, coAuthorCredit) {
+		return errors.New("Invalid co-author-credit value. Valid values are `author-only`, `full` and `fractional`")
+	}
+	return nil
+}
+
+// isSelectedAuthor reports whether the commits of the author pass --exclude-author and --dev.
+func isSelectedAuthor(authors *reportgenerator.Authors, author *reportgenerator.Author) bool {
+	for _, email := range excludedAuthors {
+		if excludedAuthor, exists := authors.Lookup(email); exists && excludedAuthor == author {
+			return false
+		}
+	}
+	if developerEmail != "_" {
+		selectedAuthor, _ := authors.Lookup(developerEmail)
+		return selectedAuthor == author
+	}
+	return true
+}
+
+// lowerCase returns a lower case copy of values, the types of the subjects are compared in lower case.
+func lowerCase(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, value := range values {
+		lowered[i] = strings.ToLower(value)
+	}
+	return lowered
+}
+
+// newGeneratorSet creates the generators with the options of the command line.
+func newGeneratorSet(authors *reportgenerator.Authors) (*generatorSet, error) {
+	commitCountDateHeatMapGenerator := reportgenerator.CommitCountDateHeatMapGenerator{CommitsMap: make(map[string]int), Identity: identity}
+	commitsPerDevReportGenerator := reportgenerator.CommitsPerDevReportGenerator{CommitsPerDevMap: make(map[string]int), CoAuthorCredit: coAuthorCredit, Authors: authors}
+	commitsPerHourReportGenerator := reportgenerator.CommitsPerHourReportGenerator{CommitsPerHourMap: make([]int, 24), Identity: identity}
+	mergeCommitsPerYearReportGenerator := reportgenerator.MergeCommitsPerYearReportGenerator{MergeCommitsPerYearMap: make(map[int]int), Identity: identity, Granularity: granularity}
+	fileTypeReportGenerator := reportgenerator.FileTypeReportGenerator{FileTypeMap: make(map[string]int), ByLanguage: fileTypesBy == "language"}
+	linesOfCodeReportGenerator := reportgenerator.LinesOfCodeReportGenerator{LinesPerLanguageMap: make(map[string]linguist.LineCounts)}
+	generalInfoReportGenerator := reportgenerator.GeneralInfoReportGenerator{CoAuthorCredit: coAuthorCredit, Authors: authors}
+	authorCommitterReportGenerator := reportgenerator.AuthorCommitterReportGenerator{Authors: authors}
+	conventionalCommitsReportGenerator := reportgenerator.ConventionalCommitsReportGenerator{Identity: identity, Types: lowerCase(conventionalTypes)}
+	messageQualityReportGenerator := reportgenerator.CommitMessageQualityReportGenerator{}
+	commitSizeReportGenerator := reportgenerator.CommitSizeReportGenerator{}
+	codeGrowthReportGenerator := reportgenerator.CodeGrowthReportGenerator{Identity: identity, Granularity: granularity}
+	developerActivityReportGenerator := reportgenerator.DeveloperActivityReportGenerator{Identity: identity, CoAuthorCredit: coAuthorCredit, Authors: authors}
+	activeContributorsReportGenerator := reportgenerator.ActiveContributorsReportGenerator{Identity: identity, Granularity: granularity, CoAuthorCredit: coAuthorCredit, Authors: authors}
+	contributorLifecycleReportGenerator := reportgenerator.ContributorLifecycleReportGenerator{Identity: identity, InactivityPeriod: time.Duration(inactiveDays) * 24 * time.Hour, CoAuthorCredit: coAuthorCredit, Authors: authors}
+	issueReferencesReportGenerator, err := reportgenerator.NewIssueReferencesReportGenerator(issuePatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &generatorSet{
+		generalInfo: &generalInfoReportGenerator,
+		fileType:    &fileTypeReportGenerator,
+		linesOfCode: &linesOfCodeReportGenerator,
+		log: []reportgenerator.LogIterationStepper{
+			&commitCountDateHeatMapGenerator,
+			&commitsPerDevReportGenerator,
+			&commitsPerHourReportGenerator,
+			&mergeCommitsPerYearReportGenerator,
+			&generalInfoReportGenerator,
+			&authorCommitterReportGenerator,
+			&conventionalCommitsReportGenerator,
+			&messageQualityReportGenerator,
+			issueReferencesReportGenerator,
+			&contributorLifecycleReportGenerator,
+			&activeContributorsReportGenerator,
+		},
+		reports: map[string]reportgenerator.ReportGenerator{
+			"general-info":           &generalInfoReportGenerator,
+			"heatmap":                &commitCountDateHeatMapGenerator,
+			"commits-per-dev":        &commitsPerDevReportGenerator,
+			"commits-per-hour":       &commitsPerHourReportGenerator,
+			"merge-commits-per-year": &mergeCommitsPerYearReportGenerator,
+			"file-types":             &fileTypeReportGenerator,
+			"lines-of-code":          &linesOfCodeReportGenerator,
+			"author-vs-committer":    &authorCommitterReportGenerator,
+			"conventional-commits":   &conventionalCommitsReportGenerator,
+			"message-quality":        &messageQualityReportGenerator,
+			"issue-references":       issueReferencesReportGenerator,
+			"commit-size":            &commitSizeReportGenerator,
+			"code-growth":            &codeGrowthReportGenerator,
+			"developer-activity":     &developerActivityReportGenerator,
+			"contributor-lifecycle":  &contributorLifecycleReportGenerator,
+			"active-contributors":    &activeContributorsReportGenerator,
+		},
+	}
+
+	statsReportGenerators := map[string]reportgenerator.StatsIterationStepper{
+		"commit-size":        &commitSizeReportGenerator,
+		"code-growth":        &codeGrowthReportGenerator,
+		"developer-activity": &developerActivityReportGenerator,
+	}
+	// Diffing every commit is the slowest part of the analysis, only do it when a report needs it
+	for _, name := range reportNames {
+		if g, exists := statsReportGenerators[name]; exists && slices.Contains(selectedReports, name) {
+			set.stats = append(set.stats, g)
+		}
+	}
+	return set, nil
+}
+
+// getReports returns the reports of a generator, several ones for the multi report generators.
+func getReports(g reportgenerator.ReportGenerator) []report.Report {
+	if multi, ok := g.(reportgenerator.MultiReportGenerator); ok {
+		return multi.GetReports()
+	}
+	return []report.Report{g.GetReport()}
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/reportgenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGeneratorSet_ConventionalTypes(t *testing.T) {
+	defer func(types []string) { conventionalTypes = types }(conventionalTypes)
+	conventionalTypes = []string{"Feat", "FIX", "deps"}
+
+	set, err := newGeneratorSet(reportgenerator.NewAuthors(nil))
+	require.NoError(t, err)
+	generator := set.reports["conventional-commits"].(*reportgenerator.ConventionalCommitsReportGenerator)
+	assert.Equal(t, []string{"feat", "fix", "deps"}, generator.Types, "The configured types should be lower cased")
+
+	for _, message := range []string{"feat: add the parser", "Fix: the parser", "DEPS: bump go-git"} {
+		commit := &object.Commit{Message: message, Author: object.Signature{Name: "Alice", When: time.Now()}}
+		generator.LogIterationStep(commit, reportgenerator.Author{Name: "Alice"})
+	}
+	assert.Equal(t, map[string]int{"feat": 1, "fix": 1, "deps": 1}, generator.TypesMap)
+}
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 )
 
 func checkIfError(err error) {
@@ -14,3 +20,57 @@ func checkIfError(err error) {
 	os.Exit(1)
 }
 
+// isInterruption reports whether the error comes from Ctrl-C or from the --timeout flag.
+func isInterruption(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// incompleteNote explains why a report only covers what was processed before the interruption.
+func incompleteNote(err error, processed string) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Sprintf("Incomplete: the analysis timed out after %s", processed)
+	}
+	return fmt.Sprintf("Incomplete: the analysis was interrupted after %s", processed)
+}
+
+// joinNotes combines the notes of a report, skipping the empty ones.
+func joinNotes(notes ...string) string {
+	var nonEmpty []string
+	for _, note := range notes {
+		if note != "" {
+			nonEmpty = append(nonEmpty, note)
+		}
+	}
+	return strings.Join(nonEmpty, ". ")
+}
+
+// setTimezone makes the --timezone flag the time zone of the dates and hours.
+func setTimezone() error {
+	if timezone == "" {
+		return nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return errors.New("Invalid timezone. Please use an IANA time zone name like Europe/Berlin.")
+	}
+	time.Local = location
+	return nil
+}
+
+// analysisContext is done on Ctrl-C and after --timeout, so that the reports can be printed with
+// what was processed so far. A second Ctrl-C kills the process as usual.
+func analysisContext() (context.Context, context.CancelFunc) {
+	interrupted, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupted.Done()
+		stop()
+	}()
+	if timeout <= 0 {
+		return interrupted, stop
+	}
+	ctx, cancel := context.WithTimeout(interrupted, timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
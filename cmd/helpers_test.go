@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"testing"
 
@@ -30,3 +33,21 @@ func TestCheckIfError(t *testing.T) {
 	})
 
 }
+
+func TestIsInterruption(t *testing.T) {
+	assert.True(t, isInterruption(context.Canceled), "Ctrl-C should be an interruption")
+	assert.True(t, isInterruption(fmt.Errorf("walk: %w", context.DeadlineExceeded)), "Wrapped timeout should be an interruption")
+	assert.False(t, isInterruption(errors.New("object not found")), "Other errors should not be interruptions")
+	assert.False(t, isInterruption(nil), "No error should not be an interruption")
+}
+
+func TestIncompleteNote(t *testing.T) {
+	assert.Equal(t, "Incomplete: the analysis timed out after 10 commits", incompleteNote(context.DeadlineExceeded, "10 commits"))
+	assert.Equal(t, "Incomplete: the analysis was interrupted after 10 commits", incompleteNote(context.Canceled, "10 commits"))
+}
+
+func TestJoinNotes(t *testing.T) {
+	assert.Equal(t, "", joinNotes("", ""), "No notes should give an empty note")
+	assert.Equal(t, "Partial", joinNotes("Partial", ""), "Empty notes should be skipped")
+	assert.Equal(t, "Partial. Incomplete", joinNotes("Partial", "Incomplete"), "Notes should be joined")
+}
@@ -6,16 +6,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/analysis"
+	"github.com/k1-end/git-reports/src/cache"
+	"github.com/k1-end/git-reports/src/linguist"
+	"github.com/k1-end/git-reports/src/pathfilter"
+	"github.com/k1-end/git-reports/src/progress"
+	"github.com/k1-end/git-reports/src/report"
 	"github.com/k1-end/git-reports/src/reportgenerator"
 	"github.com/k1-end/git-reports/src/reportprinter"
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
 )
 
 var developerEmail string
@@ -25,9 +33,30 @@ var path string
 var printerOption string
 var outputPath string
 var branch string
+var selectedReports []string
+var excludedAuthors []string
+var timezone string
+var includePaths []string
+var excludePaths []string
+var includeGenerated bool
+var fileTypesBy string
+var workers int
+var useCache bool
+var cacheFile string
+var timeout time.Duration
+var unshallow bool
+var identity reportgenerator.Identity
+var coAuthorCredit reportgenerator.CoAuthorCredit
+var issuePatterns []string
+var conventionalTypes []string
+var inactiveDays int
+var granularity reportgenerator.Granularity
+var compare string
+var compareFromDate string
+var compareToDate string
 var Version string
 
-var authors = make(map[string]*reportgenerator.Author)
+var reportNames = []string{"general-info", "heatmap", "commits-per-dev", "commits-per-hour", "merge-commits-per-year", "file-types", "lines-of-code", "author-vs-committer", "conventional-commits", "message-quality", "issue-references", "commit-size", "contributor-lifecycle", "active-contributors", "code-growth", "developer-activity"}
 
 var rootCmd = &cobra.Command{
 	Use:   "git-reports [options]",
@@ -36,59 +65,61 @@ var rootCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(0),
 
 	Run: func(cmd *cobra.Command, args []string) {
-
-        spinnerLiveText, _ := pterm.DefaultSpinner.WithRemoveWhenDone().Start("Processing the repository")
-		var fromTime, toTime time.Time
 		var err error
-		if fromDate != "" {
-			fromTime, err = time.Parse("2006-01-02", fromDate)
-			if err != nil {
-				fmt.Println("Invalid 'from' date format. Please use YYYY-MM-DD.")
-				os.Exit(1)
-			}
+		if err := setTimezone(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
 
-		if toDate != "" {
-			toTime, err = time.Parse("2006-01-02", toDate)
-			if err != nil {
-				fmt.Println("Invalid 'to' date format. Please use YYYY-MM-DD.")
-				os.Exit(1)
-			}
+		if err := checkGeneratorOptions(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
 
-		// Ensure 'from' is before 'to'
-		if fromDate != "" && toDate != "" && fromTime.After(toTime) {
-			fmt.Println("'from' date must be before 'to' date.")
+		currentPeriod, err := parsePeriod(fromDate, toDate)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		previousPeriod, comparing, err := comparedPeriod(currentPeriod, compare, compareFromDate, compareToDate, time.Now())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
 
-        if outputPath != ""  {
+        if outputPath != "" && outputPath != "-" {
             if !isValidFilePath(outputPath){
-                fmt.Println("The given output is not a valid file path or is not writable")
+                fmt.Fprintln(os.Stderr, "The given output is not a valid file path or is not writable")
                 os.Exit(1)
             }
         }
 
-        mailmapAuthors, err := ParseMailmapCommitEmailsAndName(path)
-        checkIfError(err)
-        for _, mailmapAuthor := range mailmapAuthors {
-            for email := range mailmapAuthor.Emails {
-                authors[email] = &mailmapAuthor
-                
-            }
+        if timeout < 0 {
+            fmt.Fprintln(os.Stderr, "Invalid timeout. Please use a positive duration like 10m.")
+            os.Exit(1)
         }
 
-		commitCountDateHeatMapGenerator := reportgenerator.CommitCountDateHeatMapGenerator{CommitsMap: make(map[string]int)}
-		commitsPerDevReportGenerator := reportgenerator.CommitsPerDevReportGenerator{CommitsPerDevMap: make(map[string]int)}
-		commitsPerHourReportGenerator := reportgenerator.CommitsPerHourReportGenerator{CommitsPerHourMap: make([]int, 24)}
-		mergeCommitsPerYearReportGenerator := reportgenerator.MergeCommitsPerYearReportGenerator{MergeCommitsPerYearMap: make(map[int]int)}
-		fileTypeReportGenerator := reportgenerator.FileTypeReportGenerator{FileTypeMap: make(map[string]int)}
-		generalInfoReportGenerator := reportgenerator.GeneralInfoReportGenerator{}
+        pathFilter, err := pathfilter.NewPathFilter(includePaths, excludePaths)
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(1)
+        }
 
+        mailmapAuthors, err := ParseMailmapCommitEmailsAndName(path)
+        checkIfError(err)
+        authors := reportgenerator.NewAuthors(mailmapAuthors)
+
+		current, err := newGeneratorSet(authors)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		previous, err := newGeneratorSet(authors)
+		checkIfError(err)
 
 		r, err := git.PlainOpen(path)
 		if errors.Is(err, git.ErrRepositoryNotExists) {
-			fmt.Println("The provided path is not a git repository: " + path) // no model found for id
+			fmt.Fprintln(os.Stderr, "The provided path is not a git repository: " + path) // no model found for id
 			os.Exit(1)
 		}
 		checkIfError(err)
@@ -101,9 +132,9 @@ var rootCmd = &cobra.Command{
             ref, err = r.Reference(refName, false)
             if err != nil {
                 if err == plumbing.ErrReferenceNotFound {
-                    fmt.Printf("Local branch '%s' does not exist\n", branch)
+                    fmt.Fprintf(os.Stderr, "Local branch '%s' does not exist\n", branch)
                 } else {
-                    fmt.Printf("Error checking local branch '%s': %v\n", branch, err)
+                    fmt.Fprintf(os.Stderr, "Error checking local branch '%s': %v\n", branch, err)
                 }
                 os.Exit(1)
             }
@@ -111,78 +142,182 @@ var rootCmd = &cobra.Command{
 
 		checkIfError(err)
 
-		cIter, err := r.Log(&git.LogOptions{From: ref.Hash()})
-		checkIfError(err)
+        // The progress goes to stderr so that it never ends up in a piped report, and starts once the options are valid
+        progressLine := progress.Start(os.Stderr)
 
-		_ = cIter.ForEach(func(c *object.Commit) error {
-            if _, exists := authors[c.Author.Email]; !exists {
-                authors[c.Author.Email] = &reportgenerator.Author{
-                    Name: c.Author.Name,
-                    Emails: map[string]bool{c.Author.Email: true},
-                }
-            }
+		// Ctrl-C and the timeout stop the analysis, the reports are printed with what was processed so far
+		ctx, cancel := analysisContext()
+		defer cancel()
+		messagePrinter := message.NewPrinter(language.English)
+		var historyNote, filesNote string
+		interrupted := false
 
-			if developerEmail != "_" {
-                selectedAuthor, _ := authors[developerEmail];
-                if selectedAuthor != authors[c.Author.Email] {
-                    return nil
-                }
+		shallow, err := analysis.IsShallow(r)
+		checkIfError(err)
+		if shallow && unshallow {
+			progressLine.SetPhase(progress.PhaseFetch)
+			checkIfError(analysis.Unshallow(ctx, r))
+			shallow, err = analysis.IsShallow(r)
+			checkIfError(err)
+			progressLine.SetPhase(progress.PhaseLog)
+		}
+		current.generalInfo.Shallow = shallow
+		previous.generalInfo.Shallow = shallow
+		if shallow {
+			historyNote = "Partial: the repository is a shallow clone, only the fetched commits are included"
+			if analysis.CanUnshallow(r) {
+				historyNote += ". Use --unshallow to fetch the whole history"
 			}
+		}
 
-			// Filter by date range
-			commitTime := c.Author.When
-			if fromDate != "" && commitTime.Before(fromTime) {
-				return nil
+		logOptions := analysis.LogOptions{From: ref.Hash(), Workers: workers}
+		logOptions.NeedStats = len(current.stats) > 0
+		if !pathFilter.IsEmpty() {
+			logOptions.PathFilter = pathFilter.Match
+		}
+
+		if useCache || cacheFile != "" {
+			if cacheFile == "" {
+				cacheFile, err = cache.DefaultPath(path)
+				checkIfError(err)
 			}
-			if toDate != "" && commitTime.After(toTime) {
-				return nil
+			// The cache is dropped when the identities of the authors may have changed
+			mailmapContent, _ := os.ReadFile(filepath.Join(path, ".mailmap"))
+			logOptions.Cache, err = cache.Load(cacheFile, cache.Key(string(mailmapContent)))
+			checkIfError(err)
+		}
+
+		err = analysis.WalkLog(ctx, r, logOptions, func(c *object.Commit) (reportgenerator.Author, bool) {
+            progressLine.AddCommit()
+            signature := identity.Signature(c)
+            author := authors.Resolve(signature)
+
+			if !isSelectedAuthor(authors, author) {
+				return reportgenerator.Author{}, false
 			}
 
-			commitCountDateHeatMapGenerator.LogIterationStep(c, *authors[c.Author.Email])
-			commitsPerDevReportGenerator.LogIterationStep(c, *authors[c.Author.Email])
-			commitsPerHourReportGenerator.LogIterationStep(c, *authors[c.Author.Email])
-			mergeCommitsPerYearReportGenerator.LogIterationStep(c, *authors[c.Author.Email])
-            generalInfoReportGenerator.LogIterationStep(c, *authors[c.Author.Email])
+			// Filter by date range
+			commitTime := signature.When
+			if !currentPeriod.contains(commitTime) && !(comparing && previousPeriod.contains(commitTime)) {
+				return reportgenerator.Author{}, false
+			}
 
-			return nil
+			return *author, true
+		}, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {
+			generators := current
+			if comparing && !currentPeriod.contains(identity.Signature(c).When) {
+				generators = previous
+			}
+			for _, g := range generators.log {
+				g.LogIterationStep(c, a)
+			}
+			if stats == nil {
+				return // the commit could not be diffed, e.g. the first one of a shallow clone
+			}
+			for _, g := range generators.stats {
+				g.StatsIterationStep(c, a, stats)
+			}
 		})
+		if isInterruption(err) {
+			interrupted = true
+			historyNote = joinNotes(historyNote, incompleteNote(err, messagePrinter.Sprintf("%d commits", progressLine.Commits())))
+		} else {
+			checkIfError(err)
+		}
+
+		if logOptions.Cache != nil {
+			checkIfError(logOptions.Cache.Save(cacheFile))
+		}
 
 		headRef, err := r.Head()
 		checkIfError(err)
 		commit, err := r.CommitObject(headRef.Hash())
 		checkIfError(err)
+		tree, err := commit.Tree()
+		checkIfError(err)
+		classifier, err := linguist.NewClassifier(tree)
+		checkIfError(err)
+
+        progressLine.SetPhase(progress.PhaseFiles)
+        totalFiles, err := analysis.CountFiles(tree)
+        checkIfError(err)
+        progressLine.SetTotalFiles(totalFiles)
 
-        fIter, _ := commit.Files()
-        fIter.ForEach(func(f *object.File) error {
-            fileTypeReportGenerator.FileIterationStep(f)
-            generalInfoReportGenerator.FileIterationStep(f)
-            return nil
+        // Counting lines reads every blob, only do it when the report is wanted
+        countLinesOfCode := slices.Contains(selectedReports, "lines-of-code")
+        fIter, err := commit.Files()
+        checkIfError(err)
+        err = analysis.WalkFiles(ctx, fIter, workers, func(f *object.File) {
+            defer progressLine.AddFile()
+            if !pathFilter.Match(f.Name) {
+                return
+            }
+            if includeGenerated || !classifier.Classify(f.Name).IsExcluded() {
+                current.fileType.FileIterationStep(f)
+                if countLinesOfCode {
+                    current.linesOfCode.FileIterationStep(f)
+                }
+            }
+            current.generalInfo.FileIterationStep(f)
         })
+        if isInterruption(err) {
+            interrupted = true
+            filesNote = incompleteNote(err, messagePrinter.Sprintf("%d of %d files", progressLine.Files(), totalFiles))
+        } else {
+            checkIfError(err)
+        }
 
 		absolutePath, _ := filepath.Abs(path)
 		dirName := filepath.Base(absolutePath)
 
-        spinnerLiveText.Stop()
+        progressLine.SetPhase(progress.PhaseRendering)
 		p := getPrinter(printerOption)
-		p.RegisterReport(generalInfoReportGenerator.GetReport())
-		p.RegisterReport(commitCountDateHeatMapGenerator.GetReport())
-		p.RegisterReport(commitsPerDevReportGenerator.GetReport())
-		p.RegisterReport(commitsPerHourReportGenerator.GetReport())
-		p.RegisterReport(mergeCommitsPerYearReportGenerator.GetReport())
-		p.RegisterReport(fileTypeReportGenerator.GetReport())
+		fileReports := []string{"file-types", "lines-of-code"}
+		for _, name := range reportNames {
+			if !slices.Contains(selectedReports, name) {
+				continue
+			}
+			note := historyNote
+			switch {
+			case slices.Contains(fileReports, name):
+				note = filesNote
+			case name == "general-info" && historyNote == "":
+				note = filesNote // the general info covers both the history and the files
+			}
+
+			var generatedReports []report.Report
+			if comparing && !slices.Contains(fileReports, name) {
+				generatedReports = compareReports(name, previous, current, previousPeriod.String(), currentPeriod.String())
+			} else {
+				generatedReports = getReports(current.reports[name])
+			}
+			for _, generatedReport := range generatedReports {
+				generatedReport.SetNote(joinNotes(note, generatedReport.GetNote()))
+				p.RegisterReport(generatedReport)
+			}
+		}
 		p.SetProjectTitle(dirName)
-        if outputPath != "" {
+        if outputPath != "" && outputPath != "-" {
             destination, err := os.Create(outputPath)
             if err != nil {
+                progressLine.Stop()
                 fmt.Println("os.Create:", err)
                 return
             }
             defer destination.Close()
             p.Print(destination)
+            progressLine.Stop()
         }else{
+            progressLine.Stop()
             p.Print(os.Stdout)
 
         }
+
+        if interrupted {
+            // Let scripts and CI jobs know the reports are partial
+            fmt.Fprintln(os.Stderr, "The analysis was stopped before the end, the reports are incomplete")
+            os.Exit(1)
+        }
 	},
 }
 
@@ -205,19 +340,41 @@ func init() {
     rootCmd.PersistentFlags().StringVarP(&toDate, "to", "t", "", "Filter commits up to this date (format: YYYY-MM-DD)")
     rootCmd.PersistentFlags().StringVarP(&branch, "branch", "b", "", "Set the branch to analyze")
     rootCmd.PersistentFlags().StringVar(&printerOption, "printer", "console", "Printer (default to console) (available options are console and html)")
-    rootCmd.PersistentFlags().StringVar(&outputPath, "output", "", "Output path for the report")
+    rootCmd.PersistentFlags().StringVar(&outputPath, "output", "", "Output path for the report, - for stdout (default to stdout)")
+    rootCmd.PersistentFlags().StringSliceVar(&selectedReports, "reports", reportNames, "Reports to generate (comma separated)")
+    rootCmd.PersistentFlags().StringSliceVar(&excludedAuthors, "exclude-author", nil, "Exclude commits of the developers with these emails (comma separated)")
+    rootCmd.PersistentFlags().StringSliceVar(&includePaths, "include-path", nil, "Only analyze the files matching these glob patterns (comma separated)")
+    rootCmd.PersistentFlags().StringSliceVar(&excludePaths, "exclude-path", nil, "Do not analyze the files matching these glob patterns (comma separated)")
+    rootCmd.PersistentFlags().BoolVar(&includeGenerated, "include-generated", false, "Include the files marked as linguist-generated, linguist-vendored or linguist-documentation in .gitattributes in the file type and lines of code reports")
+    rootCmd.PersistentFlags().StringVar(&fileTypesBy, "file-types-by", "language", "Group the file type report by language or by file extension (available options are language and extension)")
+    rootCmd.PersistentFlags().IntVar(&workers, "workers", analysis.DefaultWorkers, "Number of goroutines processing commits and files")
+    rootCmd.PersistentFlags().BoolVar(&useCache, "cache", false, "Keep the facts of the analyzed commits in a cache file to only process the new commits on the next run")
+    rootCmd.PersistentFlags().StringVar(&cacheFile, "cache-file", "", "Path of the cache file, implies --cache (default to a file in the user cache directory)")
+    rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Stop the analysis after this duration, e.g. 10m, and print the incomplete reports (default to no timeout)")
+    rootCmd.PersistentFlags().BoolVar(&unshallow, "unshallow", false, "Fetch the whole history from the origin remote first when the repository is a shallow clone")
+    rootCmd.PersistentFlags().StringVar((*string)(&identity), "identity", string(reportgenerator.IdentityAuthor), "Attribute the commits to their author or to their committer (available options are author and committer)")
+    rootCmd.PersistentFlags().StringVar((*string)(&coAuthorCredit), "co-author-credit", string(reportgenerator.CoAuthorCreditAuthorOnly), "Credit of the developers listed in the Co-authored-by trailers in the developer and contributor reports, except the commit message quality and commit size ones (available options are author-only, full and fractional)")
+    rootCmd.PersistentFlags().StringSliceVar(&conventionalTypes, "conventional-types", reportgenerator.DefaultConventionalTypes, "Types accepted by the Conventional Commits report (comma separated)")
+    rootCmd.PersistentFlags().StringArrayVar(&issuePatterns, "issue-pattern", reportgenerator.DefaultIssuePatterns, "Regular expression matching the issue keys in the commit messages, repeat the flag for several patterns")
+    rootCmd.PersistentFlags().StringVar(&compare, "compare", "", "Compare the reports with the period of the same length just before --from and --to (available option is previous)")
+    rootCmd.PersistentFlags().StringVar(&compareFromDate, "compare-from", "", "Compare the reports with the period starting at this date (format: YYYY-MM-DD)")
+    rootCmd.PersistentFlags().StringVar(&compareToDate, "compare-to", "", "Compare the reports with the period ending at this date (format: YYYY-MM-DD)")
+    rootCmd.PersistentFlags().StringVar((*string)(&granularity), "granularity", "", "Length of the periods of the reports over time (available options are day, week, month, quarter and year) (default to month, and year for the merge commits report)")
+    rootCmd.PersistentFlags().IntVar(&inactiveDays, "inactive-days", int(reportgenerator.DefaultInactivityPeriod.Hours()/24), "Number of days without commits after which a developer is listed as inactive in the contributor lifecycle report")
+    rootCmd.PersistentFlags().StringVar(&timezone, "timezone", "", "Time zone used for dates and hours, e.g. Europe/Berlin (default to the local time zone)")
 
     rootCmd.Flags().BoolP("version", "v", false, "Print the version") // Subcommands do not automatically inherit this flag
     rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-        versionFlag, err := cmd.Flags().GetBool("version")
-        if err != nil {
-            return err
-        }
-
-        if versionFlag {
+        // Only the root command has the flag
+        if versionFlag, err := cmd.Flags().GetBool("version"); err == nil && versionFlag {
             fmt.Println("Version:", Version)
             os.Exit(0)
         }
+
+        if err := loadConfig(cmd, path); err != nil {
+            cmd.SilenceUsage = true
+            return err
+        }
         return nil
     }
 }
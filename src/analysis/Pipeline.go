@@ -0,0 +1,256 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/k1-end/git-reports/src/cache"
+	"github.com/k1-end/git-reports/src/reportgenerator"
+)
+
+// DefaultWorkers is the default number of goroutines processing commits and files.
+var DefaultWorkers = runtime.NumCPU()
+
+// CommitFilter runs on the goroutine walking the log, in walk order, so it may keep state
+// without locking. It returns the resolved author of the commit and whether to analyze it.
+type CommitFilter func(c *object.Commit) (reportgenerator.Author, bool)
+
+// CommitStep processes a single commit. It is called concurrently by the workers.
+// stats is nil unless LogOptions asked for it, and when it could not be computed, e.g. for the
+// first commit of a shallow clone. It is empty but not nil for a commit without changes.
+type CommitStep func(c *object.Commit, a reportgenerator.Author, stats object.FileStats)
+
+// FileStep processes a single file. It is called concurrently by the workers.
+type FileStep func(f *object.File)
+
+// LogOptions configure WalkLog.
+type LogOptions struct {
+    From       plumbing.Hash     // commit to walk the history from
+    Exclude    plumbing.Hash     // do not walk the history of this commit, like git log Exclude..From, may be zero
+    Workers    int
+    PathFilter func(string) bool // only analyze the commits touching a matching path and their changes of these paths, may be nil
+    NeedStats  bool              // compute the diff stats of the analyzed commits
+    Cache      *cache.Cache      // facts of the commits analyzed by previous runs, may be nil
+}
+
+type commitTask struct {
+    commit   *object.Commit
+    facts    *cache.CommitFacts // nil when the commit is not cached yet
+    author   reportgenerator.Author
+    accepted bool
+}
+
+// WalkLog walks the history on a single goroutine and fans the commits accepted by the
+// filter out to a pool of workers calling step.
+//
+// When ctx is done the walk stops, the commits already handed to the workers are dropped
+// and ctx.Err() is returned: the steps only saw a part of the history.
+//
+// Commits found in the cache are neither read from the repository nor diffed again, and
+// their parents are taken from the cache too. The commits that are not cached yet are
+// added to it, even the ones rejected by the filter, so that the next run can skip them.
+func WalkLog(ctx context.Context, r *git.Repository, o LogOptions, filter CommitFilter, step CommitStep) error {
+    needFacts := o.NeedStats || o.PathFilter != nil || o.Cache != nil
+
+    tasks := make(chan commitTask, o.Workers*4)
+    wg := startWorkers(o.Workers, func() {
+        for task := range tasks {
+            if ctx.Err() != nil {
+                continue
+            }
+            var stats object.FileStats
+            if task.facts != nil {
+                stats = computedStats(task.facts.Stats)
+            } else if needFacts {
+                // The stats can not be computed when the parent is missing, e.g. in a shallow clone
+                facts, err := cache.NewCommitFacts(ctx, task.commit)
+                if ctx.Err() != nil {
+                    continue // the diff was interrupted
+                }
+                if err == nil {
+                    stats = computedStats(facts.Stats)
+                    if o.Cache != nil {
+                        o.Cache.Put(facts)
+                    }
+                }
+            }
+
+            if !task.accepted {
+                continue
+            }
+            if o.PathFilter != nil {
+                // The steps only see the changes of the matching paths, the commits that can
+                // not be diffed are left out since it is unknown whether they touch them
+                stats = filterStats(stats, o.PathFilter)
+                if len(stats) == 0 {
+                    continue
+                }
+            }
+            step(task.commit, task.author, stats)
+        }
+    })
+
+    err := walkHistory(ctx, r, o, func(task commitTask) {
+        task.author, task.accepted = filter(task.commit)
+        if task.accepted || (task.facts == nil && o.Cache != nil) {
+            tasks <- task
+        }
+    })
+    close(tasks)
+    wg.Wait()
+    if err == nil {
+        err = ctx.Err() // the workers may have dropped some commits after the walk ended
+    }
+    return err
+}
+
+// walkHistory visits every commit reachable from o.From and not from o.Exclude once, depth first:
+// the first parents down to the root, then the branches merged on the way. The commits of a merged
+// branch are visited after the commits they branched off from, it is not a topological order.
+func walkHistory(ctx context.Context, r *git.Repository, o LogOptions, visit func(task commitTask)) error {
+    stack := []plumbing.Hash{o.From}
+    seen := make(map[plumbing.Hash]bool)
+    if !o.Exclude.IsZero() {
+        // The walk stops where it meets the history of Exclude
+        err := walkHistory(ctx, r, LogOptions{From: o.Exclude, Cache: o.Cache}, func(task commitTask) {
+            seen[task.commit.Hash] = true
+        })
+        if err != nil {
+            return err
+        }
+    }
+    for len(stack) > 0 {
+        if err := ctx.Err(); err != nil {
+            return err
+        }
+        h := stack[len(stack)-1]
+        stack = stack[:len(stack)-1]
+        if seen[h] {
+            continue
+        }
+        seen[h] = true
+
+        var task commitTask
+        if facts, exists := lookup(o.Cache, h); exists {
+            task = commitTask{commit: facts.Commit(), facts: facts}
+        } else {
+            c, err := r.CommitObject(h)
+            if errors.Is(err, plumbing.ErrObjectNotFound) && h != o.From {
+                continue // the history of shallow clones stops at missing parents
+            }
+            if err != nil {
+                return err
+            }
+            task = commitTask{commit: c}
+        }
+        visit(task)
+
+        // Push the parents in reverse order so that the first parent is walked first, like git log
+        parents := task.commit.ParentHashes
+        for i := len(parents) - 1; i >= 0; i-- {
+            stack = append(stack, parents[i])
+        }
+    }
+    return nil
+}
+
+func lookup(c *cache.Cache, h plumbing.Hash) (*cache.CommitFacts, bool) {
+    if c == nil {
+        return nil, false
+    }
+    return c.Get(h)
+}
+
+// computedStats tells the stats of a commit without changes from stats that could not be computed.
+func computedStats(stats object.FileStats) object.FileStats {
+    if stats == nil {
+        return object.FileStats{}
+    }
+    return stats
+}
+
+func filterStats(stats object.FileStats, pathFilter func(string) bool) object.FileStats {
+    var filtered object.FileStats
+    for _, stat := range stats {
+        if pathFilter(stat.Name) {
+            filtered = append(filtered, stat)
+        }
+    }
+    return filtered
+}
+
+// WalkFiles walks the files of the iterator on a single goroutine and fans them out to a
+// pool of workers calling step. Like WalkLog, it stops and returns ctx.Err() when ctx is done.
+func WalkFiles(ctx context.Context, iter *object.FileIter, workers int, step FileStep) error {
+    files := make(chan *object.File, workers*4)
+    wg := startWorkers(workers, func() {
+        for f := range files {
+            if ctx.Err() == nil {
+                step(f)
+            }
+        }
+    })
+
+    err := iter.ForEach(func(f *object.File) error {
+        if ctx.Err() != nil {
+            return storer.ErrStop
+        }
+        files <- f
+        return nil
+    })
+    close(files)
+    wg.Wait()
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    return ignoreStop(err)
+}
+
+func startWorkers(workers int, work func()) *sync.WaitGroup {
+    if workers < 1 {
+        workers = 1
+    }
+    var wg sync.WaitGroup
+    for i := 0; i < workers; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            work()
+        }()
+    }
+    return &wg
+}
+
+func ignoreStop(err error) error {
+    if errors.Is(err, storer.ErrStop) {
+        return nil
+    }
+    return err
+}
+
+// CountFiles returns the number of files of the tree without reading their content.
+func CountFiles(tree *object.Tree) (int, error) {
+    walker := object.NewTreeWalker(tree, true, nil)
+    defer walker.Close()
+    count := 0
+    for {
+        _, entry, err := walker.Next()
+        if errors.Is(err, io.EOF) {
+            return count, nil
+        }
+        if err != nil {
+            return 0, err
+        }
+        if entry.Mode != filemode.Dir && entry.Mode != filemode.Submodule {
+            count++
+        }
+    }
+}
@@ -0,0 +1,314 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/k1-end/git-reports/src/cache"
+	"github.com/k1-end/git-reports/src/reportgenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createMockRepository creates an in-memory repository with one file added per commit.
+func createMockRepository(t *testing.T, commits int) *git.Repository {
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	start := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < commits; i++ {
+		name := fmt.Sprintf("file%d.go", i)
+		require.NoError(t, util.WriteFile(fs, name, []byte("package main\n"), 0644))
+		_, err = w.Add(name)
+		require.NoError(t, err)
+		author := fmt.Sprintf("Author %d", i%3)
+		_, err = w.Commit("Commit "+name, &git.CommitOptions{
+			Author: &object.Signature{Name: author, Email: fmt.Sprintf("author%d@example.com", i%3), When: start.Add(time.Duration(i) * time.Hour)},
+		})
+		require.NoError(t, err)
+	}
+	return r
+}
+
+func headHash(t *testing.T, r *git.Repository) plumbing.Hash {
+	head, err := r.Head()
+	require.NoError(t, err)
+	return head.Hash()
+}
+
+func TestWalkLog(t *testing.T) {
+	r := createMockRepository(t, 50)
+
+	var filtered []string // only touched by the walking goroutine
+	var mu sync.Mutex
+	seen := make(map[string]int)
+	generator := reportgenerator.CommitsPerDevReportGenerator{CommitsPerDevMap: make(map[string]int)}
+
+	err := WalkLog(context.Background(), r, LogOptions{From: headHash(t, r), Workers: 4}, func(c *object.Commit) (reportgenerator.Author, bool) {
+		filtered = append(filtered, c.Hash.String())
+		return reportgenerator.Author{Name: c.Author.Name}, c.Author.Email != "author0@example.com"
+	}, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {
+		assert.Nil(t, stats, "Stats should not be computed when not needed")
+		mu.Lock()
+		seen[c.Hash.String()]++
+		mu.Unlock()
+		generator.LogIterationStep(c, a)
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, filtered, 50, "The filter should see every commit")
+	assert.Len(t, seen, 33, "Only the accepted commits should be processed")
+	for hash, count := range seen {
+		assert.Equal(t, 1, count, "Commit %s should be processed once", hash)
+	}
+	assert.Equal(t, map[string]int{"Author 1": 17, "Author 2": 16}, generator.CommitsPerDevMap, "Generators should receive the resolved authors")
+}
+
+func TestWalkFiles(t *testing.T) {
+	r := createMockRepository(t, 20)
+	head, err := r.Head()
+	require.NoError(t, err)
+	commit, err := r.CommitObject(head.Hash())
+	require.NoError(t, err)
+	iter, err := commit.Files()
+	require.NoError(t, err)
+
+	var count atomic.Int32
+	generator := reportgenerator.GeneralInfoReportGenerator{}
+	err = WalkFiles(context.Background(), iter, 8, func(f *object.File) {
+		count.Add(1)
+		generator.FileIterationStep(f)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(20), count.Load(), "Every file should be processed")
+	assert.Equal(t, 20, generator.FilesNo, "Generators should count every file")
+	assert.Equal(t, uint64(20*len("package main\n")), generator.ProjectSize, "Generators should sum every file size")
+}
+
+func TestCountFiles(t *testing.T) {
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	for _, name := range []string{"main.go", "src/app.go", "src/lib/lib.go"} {
+		require.NoError(t, util.WriteFile(fs, name, []byte("package main\n"), 0644))
+		_, err = w.Add(name)
+		require.NoError(t, err)
+	}
+	hash, err := w.Commit("Add files", &git.CommitOptions{
+		Author: &object.Signature{Name: "Author A", Email: "authora@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	commit, err := r.CommitObject(hash)
+	require.NoError(t, err)
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+
+	count, err := CountFiles(tree)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count, "Files in sub directories should be counted, not the directories")
+}
+
+func TestWalkLog_NoWorkers(t *testing.T) {
+	r := createMockRepository(t, 5)
+
+	var count atomic.Int32
+	err := WalkLog(context.Background(), r, LogOptions{From: headHash(t, r)}, acceptAll, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {
+		count.Add(1)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), count.Load(), "A single worker should be used when none is asked")
+}
+
+func TestWalkLog_EmptyCommitStats(t *testing.T) {
+	r := createMockRepository(t, 1)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	_, err = w.Commit("Empty", &git.CommitOptions{
+		Author:            &object.Signature{Name: "Author A", Email: "authora@example.com", When: time.Now()},
+		AllowEmptyCommits: true,
+	})
+	require.NoError(t, err)
+
+	var stats object.FileStats
+	err = WalkLog(context.Background(), r, LogOptions{From: headHash(t, r), Workers: 1, NeedStats: true}, acceptAll, func(c *object.Commit, a reportgenerator.Author, s object.FileStats) {
+		if c.Message == "Empty" {
+			stats = s
+		}
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, stats, "The stats of a commit without changes should be told from missing ones")
+	assert.Empty(t, stats)
+}
+
+func acceptAll(c *object.Commit) (reportgenerator.Author, bool) {
+	return reportgenerator.Author{Name: c.Author.Name}, true
+}
+
+func TestWalkLog_PathFilter(t *testing.T) {
+	r := createMockRepository(t, 10)
+
+	var mu sync.Mutex
+	var messages []string
+	options := LogOptions{From: headHash(t, r), Workers: 2, PathFilter: func(p string) bool { return p == "file3.go" || p == "file7.go" }}
+	err := WalkLog(context.Background(), r, options, acceptAll, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {
+		mu.Lock()
+		defer mu.Unlock()
+		messages = append(messages, c.Message)
+		assert.Len(t, stats, 1, "Stats should be computed for the path filter")
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Commit file3.go", "Commit file7.go"}, messages, "Only the commits touching the paths should be processed")
+}
+
+func TestWalkLog_PathFilterStats(t *testing.T) {
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, util.WriteFile(fs, "go.mod", []byte("module example\n"), 0644))
+	require.NoError(t, util.WriteFile(fs, "main.go", []byte("package main\n\nfunc main() {}\n"), 0644))
+	_, err = w.Add(".")
+	require.NoError(t, err)
+	hash, err := w.Commit("Initial commit", &git.CommitOptions{Author: &object.Signature{Name: "Author", Email: "author@example.com", When: time.Now()}})
+	require.NoError(t, err)
+
+	var stats object.FileStats
+	options := LogOptions{From: hash, Workers: 1, NeedStats: true, PathFilter: func(p string) bool { return p == "go.mod" }}
+	err = WalkLog(context.Background(), r, options, acceptAll, func(c *object.Commit, a reportgenerator.Author, s object.FileStats) {
+		stats = s
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 1, "The changes of the other paths should be left out")
+	assert.Equal(t, "go.mod", stats[0].Name)
+	assert.Equal(t, 1, stats[0].Addition)
+}
+
+func TestWalkLog_Exclude(t *testing.T) {
+	r := createMockRepository(t, 10)
+	exclude, err := r.ResolveRevision("HEAD~3")
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var messages []string
+	options := LogOptions{From: headHash(t, r), Exclude: *exclude, Workers: 2}
+	err = WalkLog(context.Background(), r, options, acceptAll, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {
+		mu.Lock()
+		defer mu.Unlock()
+		messages = append(messages, c.Message)
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Commit file7.go", "Commit file8.go", "Commit file9.go"}, messages, "Only the commits missing from the excluded history should be processed")
+}
+
+func TestWalkLog_Merge(t *testing.T) {
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	commit := func(message string, parents ...plumbing.Hash) plumbing.Hash {
+		require.NoError(t, util.WriteFile(fs, message, []byte(message), 0644))
+		_, err := w.Add(message)
+		require.NoError(t, err)
+		hash, err := w.Commit(message, &git.CommitOptions{
+			Author:  &object.Signature{Name: "Author", Email: "author@example.com", When: time.Now()},
+			Parents: parents,
+		})
+		require.NoError(t, err)
+		return hash
+	}
+	root := commit("root")
+	feature := commit("feature", root)
+	main := commit("main", root)
+	merge := commit("merge", main, feature)
+
+	var messages []string
+	err = WalkLog(context.Background(), r, LogOptions{From: merge, Workers: 1}, func(c *object.Commit) (reportgenerator.Author, bool) {
+		messages = append(messages, c.Message)
+		return reportgenerator.Author{}, false
+	}, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"merge", "main", "root", "feature"}, messages, "The first parents should be walked first and every commit once")
+}
+
+func TestWalkLog_Cache(t *testing.T) {
+	r := createMockRepository(t, 10)
+	c, err := cache.Load(filepath.Join(t.TempDir(), "cache"), cache.Key())
+	require.NoError(t, err)
+
+	// The first run fills the cache, even with the commits rejected by the filter
+	options := LogOptions{From: headHash(t, r), Workers: 2, Cache: c}
+	err = WalkLog(context.Background(), r, options, func(c *object.Commit) (reportgenerator.Author, bool) {
+		return reportgenerator.Author{}, c.Message == "Commit file0.go"
+	}, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {})
+	require.NoError(t, err)
+	assert.Equal(t, 10, c.Len(), "Every commit should be cached")
+
+	// The second run reads every commit from the cache, not from the repository
+	empty, err := git.Init(memory.NewStorage(), nil)
+	require.NoError(t, err)
+	var count atomic.Int32
+	err = WalkLog(context.Background(), empty, options, acceptAll, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {
+		count.Add(1)
+		assert.Len(t, stats, 1, "Cached stats should be given to the step")
+		assert.Equal(t, "Author", c.Author.Name[:6], "Cached commits should keep their author")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(10), count.Load(), "Cached commits should be walked through their cached parents")
+}
+
+func TestWalkLog_Cancelled(t *testing.T) {
+	r := createMockRepository(t, 50)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	walked := 0
+	var count atomic.Int32
+	err := WalkLog(ctx, r, LogOptions{From: headHash(t, r), Workers: 2}, func(c *object.Commit) (reportgenerator.Author, bool) {
+		walked++
+		if walked == 10 {
+			cancel()
+		}
+		return reportgenerator.Author{}, true
+	}, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {
+		count.Add(1)
+	})
+	assert.ErrorIs(t, err, context.Canceled, "Cancellation should be reported")
+	assert.Equal(t, 10, walked, "The walk should stop once cancelled")
+	assert.LessOrEqual(t, count.Load(), int32(10), "Only the commits walked before the cancellation may be processed")
+}
+
+func TestWalkFiles_Cancelled(t *testing.T) {
+	r := createMockRepository(t, 20)
+	commit, err := r.CommitObject(headHash(t, r))
+	require.NoError(t, err)
+	iter, err := commit.Files()
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var count atomic.Int32
+	err = WalkFiles(ctx, iter, 2, func(f *object.File) {
+		count.Add(1)
+	})
+	assert.ErrorIs(t, err, context.Canceled, "Cancellation should be reported")
+	assert.Equal(t, int32(0), count.Load(), "No file should be processed once cancelled")
+}
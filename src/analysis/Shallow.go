@@ -0,0 +1,72 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// DefaultRemote is the remote fetched from when the history of a shallow clone is completed.
+const DefaultRemote = "origin"
+
+// IsShallow reports whether the repository is a shallow clone (it has a .git/shallow file),
+// in which case its history stops at the commits listed in that file.
+func IsShallow(r *git.Repository) (bool, error) {
+    shallows, err := r.Storer.Shallow()
+    if err != nil {
+        return false, err
+    }
+    return len(shallows) > 0, nil
+}
+
+// CanUnshallow reports whether the missing history could be fetched from DefaultRemote.
+func CanUnshallow(r *git.Repository) bool {
+    _, err := r.Remote(DefaultRemote)
+    return err == nil
+}
+
+// Unshallow fetches the whole history of a shallow clone from DefaultRemote.
+func Unshallow(ctx context.Context, r *git.Repository) error {
+    // Like git fetch --unshallow, ask for a depth deeper than any history
+    err := r.FetchContext(ctx, &git.FetchOptions{RemoteName: DefaultRemote, Depth: math.MaxInt32, Tags: git.NoTags})
+    if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+        return err
+    }
+
+    // go-git only ever adds commits to .git/shallow, drop the ones whose parents were fetched
+    shallows, err := r.Storer.Shallow()
+    if err != nil {
+        return err
+    }
+    var remaining []plumbing.Hash
+    for _, h := range shallows {
+        if !hasParents(r, h) {
+            remaining = append(remaining, h)
+        }
+    }
+    if err := r.Storer.SetShallow(remaining); err != nil {
+        return err
+    }
+    // git still sees an empty .git/shallow file as a shallow clone
+    if storage, ok := r.Storer.(*filesystem.Storage); ok && len(remaining) == 0 {
+        return storage.Filesystem().Remove("shallow")
+    }
+    return nil
+}
+
+func hasParents(r *git.Repository, h plumbing.Hash) bool {
+    c, err := r.CommitObject(h)
+    if err != nil {
+        return false
+    }
+    for _, parent := range c.ParentHashes {
+        if _, err := r.CommitObject(parent); err != nil {
+            return false
+        }
+    }
+    return true
+}
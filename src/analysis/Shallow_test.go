@@ -0,0 +1,84 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/reportgenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createShallowClone clones a repository of the given number of commits with a depth of 1.
+func createShallowClone(t *testing.T, commits int) *git.Repository {
+	source := filepath.Join(t.TempDir(), "source")
+	r, err := git.PlainInit(source, false)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	for i := 0; i < commits; i++ {
+		name := fmt.Sprintf("file%d.go", i)
+		require.NoError(t, os.WriteFile(filepath.Join(source, name), []byte("package main\n"), 0644))
+		_, err = w.Add(name)
+		require.NoError(t, err)
+		_, err = w.Commit("Commit "+name, &git.CommitOptions{
+			Author: &object.Signature{Name: "Author A", Email: "authora@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+	}
+
+	clone, err := git.PlainClone(filepath.Join(t.TempDir(), "clone"), false, &git.CloneOptions{URL: source, Depth: 1})
+	require.NoError(t, err)
+	return clone
+}
+
+func countCommits(t *testing.T, r *git.Repository) int {
+	count := 0
+	err := WalkLog(context.Background(), r, LogOptions{From: headHash(t, r), Workers: 1}, acceptAll, func(c *object.Commit, a reportgenerator.Author, stats object.FileStats) {
+		count++
+	})
+	require.NoError(t, err)
+	return count
+}
+
+func TestIsShallow(t *testing.T) {
+	shallow, err := IsShallow(createMockRepository(t, 3))
+	require.NoError(t, err)
+	assert.False(t, shallow, "Repository with its whole history should not be shallow")
+
+	clone := createShallowClone(t, 3)
+	shallow, err = IsShallow(clone)
+	require.NoError(t, err)
+	assert.True(t, shallow, "Clone with a depth should be shallow")
+	assert.Equal(t, 1, countCommits(t, clone), "The history of a shallow clone should stop at the missing parents")
+}
+
+func TestUnshallow(t *testing.T) {
+	clone := createShallowClone(t, 3)
+	require.True(t, CanUnshallow(clone), "Clones should have a remote")
+	assert.False(t, CanUnshallow(createMockRepository(t, 1)), "Repositories without a remote can not be completed")
+
+	require.NoError(t, Unshallow(context.Background(), clone))
+	assert.Equal(t, 3, countCommits(t, clone), "The whole history should be fetched")
+	shallow, err := IsShallow(clone)
+	require.NoError(t, err)
+	assert.False(t, shallow, "Clone should not be shallow anymore")
+}
+
+func TestWalkLog_ShallowStats(t *testing.T) {
+	clone := createShallowClone(t, 2)
+
+	var stats []object.FileStats
+	err := WalkLog(context.Background(), clone, LogOptions{From: headHash(t, clone), Workers: 1, NeedStats: true}, acceptAll, func(c *object.Commit, a reportgenerator.Author, s object.FileStats) {
+		stats = append(stats, s)
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Nil(t, stats[0], "The stats of a commit whose parent is missing can not be computed")
+}
@@ -0,0 +1,189 @@
+package cache
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// formatVersion is part of the cache key, bump it whenever CommitFacts changes.
+const formatVersion = 1
+
+// CommitFacts is everything the reports need to know about a commit.
+type CommitFacts struct {
+    Hash         plumbing.Hash
+    Author       object.Signature
+    Committer    object.Signature
+    ParentHashes []plumbing.Hash
+    Message      string
+    Stats        object.FileStats // lines added and deleted per touched path, compared to the first parent
+}
+
+// NewCommitFacts extracts the facts of a commit. Computing the diff stats is the expensive
+// part, it is interrupted when ctx is done.
+func NewCommitFacts(ctx context.Context, c *object.Commit) (*CommitFacts, error) {
+    stats, err := c.StatsContext(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return &CommitFacts{
+        Hash:         c.Hash,
+        Author:       c.Author,
+        Committer:    c.Committer,
+        ParentHashes: c.ParentHashes,
+        Message:      c.Message,
+        Stats:        stats,
+    }, nil
+}
+
+// Commit rebuilds the commit from its facts. The returned commit is not attached to
+// a repository: only its fields can be used, not the methods reading other objects.
+func (f *CommitFacts) Commit() *object.Commit {
+    return &object.Commit{
+        Hash:         f.Hash,
+        Author:       f.Author,
+        Committer:    f.Committer,
+        ParentHashes: f.ParentHashes,
+        Message:      f.Message,
+    }
+}
+
+// Cache keeps the facts of the commits analyzed by previous runs. It is safe for concurrent use.
+type Cache struct {
+    key     string
+    commits map[plumbing.Hash]*CommitFacts
+    dirty   bool
+    mu      sync.RWMutex
+}
+
+type cacheFile struct {
+    Key     string
+    Commits []*CommitFacts
+}
+
+// Key builds a cache key from everything that changes the meaning of the cached facts,
+// like the content of the .mailmap file or the analysis options.
+func Key(parts ...string) string {
+    h := sha256.New()
+    fmt.Fprintf(h, "v%d", formatVersion)
+    for _, part := range parts {
+        fmt.Fprintf(h, "\x00%s", part)
+    }
+    return hex.EncodeToString(h.Sum(nil))
+}
+
+// DefaultPath returns the cache file used for the repository when no path is given.
+func DefaultPath(repoPath string) (string, error) {
+    cacheDir, err := os.UserCacheDir()
+    if err != nil {
+        return "", err
+    }
+    absolutePath, err := filepath.Abs(repoPath)
+    if err != nil {
+        return "", err
+    }
+    sum := sha256.Sum256([]byte(absolutePath))
+    return filepath.Join(cacheDir, "git-reports", hex.EncodeToString(sum[:8])+".cache"), nil
+}
+
+// Load reads the cache file. A missing file, a file written with another key or an
+// unreadable file gives an empty cache: the cache is rebuilt instead of failing the run.
+func Load(path string, key string) (*Cache, error) {
+    c := &Cache{key: key, commits: make(map[plumbing.Hash]*CommitFacts)}
+
+    file, err := os.Open(path)
+    if errors.Is(err, fs.ErrNotExist) {
+        return c, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+
+    reader, err := gzip.NewReader(file)
+    if err != nil {
+        return c, nil
+    }
+    defer reader.Close()
+
+    var content cacheFile
+    if err := gob.NewDecoder(reader).Decode(&content); err != nil || content.Key != key {
+        return c, nil
+    }
+    for _, facts := range content.Commits {
+        c.commits[facts.Hash] = facts
+    }
+    return c, nil
+}
+
+// Get returns the cached facts of a commit.
+func (c *Cache) Get(h plumbing.Hash) (*CommitFacts, bool) {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+    facts, exists := c.commits[h]
+    return facts, exists
+}
+
+// Put adds the facts of a commit to the cache.
+func (c *Cache) Put(facts *CommitFacts) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.commits[facts.Hash] = facts
+    c.dirty = true
+}
+
+// Len returns the number of cached commits.
+func (c *Cache) Len() int {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+    return len(c.commits)
+}
+
+// Save writes the cache file if new commits were added since it was loaded.
+func (c *Cache) Save(path string) error {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+    if !c.dirty {
+        return nil
+    }
+
+    if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+        return err
+    }
+    // Write to a temporary file first so that an interrupted run never leaves a truncated cache
+    tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+    if err != nil {
+        return err
+    }
+    defer os.Remove(tmp.Name())
+
+    content := cacheFile{Key: c.key, Commits: make([]*CommitFacts, 0, len(c.commits))}
+    for _, facts := range c.commits {
+        content.Commits = append(content.Commits, facts)
+    }
+
+    writer := gzip.NewWriter(tmp)
+    if err := gob.NewEncoder(writer).Encode(content); err != nil {
+        tmp.Close()
+        return err
+    }
+    if err := writer.Close(); err != nil {
+        tmp.Close()
+        return err
+    }
+    if err := tmp.Close(); err != nil {
+        return err
+    }
+    return os.Rename(tmp.Name(), path)
+}
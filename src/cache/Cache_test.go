@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockFacts(hash string, message string) *CommitFacts {
+	when := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+	return &CommitFacts{
+		Hash:         plumbing.NewHash(hash),
+		Author:       object.Signature{Name: "Author A", Email: "authora@example.com", When: when},
+		Committer:    object.Signature{Name: "Author B", Email: "authorb@example.com", When: when.Add(time.Hour)},
+		ParentHashes: []plumbing.Hash{plumbing.NewHash("1111111111111111111111111111111111111111")},
+		Message:      message,
+		Stats:        object.FileStats{{Name: "main.go", Addition: 10, Deletion: 2}},
+	}
+}
+
+func TestCache_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "repo.cache")
+	c, err := Load(path, Key("mailmap"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, c.Len(), "Missing cache file should give an empty cache")
+
+	facts := createMockFacts("2222222222222222222222222222222222222222", "First commit")
+	c.Put(facts)
+	require.NoError(t, c.Save(path))
+
+	loaded, err := Load(path, Key("mailmap"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded.Len(), "Saved commits should be loaded")
+	cached, exists := loaded.Get(facts.Hash)
+	require.True(t, exists, "Saved commit should be found by its hash")
+	assert.Equal(t, facts.Message, cached.Message)
+	assert.Equal(t, facts.Stats, cached.Stats)
+	assert.True(t, facts.Author.When.Equal(cached.Author.When), "Dates should survive the cache")
+	_, offset := cached.Author.When.Zone()
+	assert.Equal(t, 3600, offset, "Time zones should survive the cache")
+}
+
+func TestCache_KeyMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repo.cache")
+	c, err := Load(path, Key("old mailmap"))
+	require.NoError(t, err)
+	c.Put(createMockFacts("2222222222222222222222222222222222222222", "First commit"))
+	require.NoError(t, c.Save(path))
+
+	loaded, err := Load(path, Key("new mailmap"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, loaded.Len(), "Cache written with another key should be dropped")
+}
+
+func TestCache_CorruptedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repo.cache")
+	require.NoError(t, os.WriteFile(path, []byte("not a cache"), 0644))
+
+	c, err := Load(path, Key())
+	require.NoError(t, err, "Corrupted cache should not fail the run")
+	assert.Equal(t, 0, c.Len(), "Corrupted cache should be dropped")
+}
+
+func TestCache_SaveUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repo.cache")
+	c, err := Load(path, Key())
+	require.NoError(t, err)
+	require.NoError(t, c.Save(path))
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "Unchanged cache should not be written")
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, Key("a", "b"), Key("a", "b"), "Key should be stable")
+	assert.NotEqual(t, Key("a", "b"), Key("ab"), "Parts should not be concatenated")
+}
+
+func TestNewCommitFacts(t *testing.T) {
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, util.WriteFile(fs, "main.go", []byte("package main\n\nfunc main() {}\n"), 0644))
+	_, err = w.Add("main.go")
+	require.NoError(t, err)
+	hash, err := w.Commit("Add main", &git.CommitOptions{
+		Author: &object.Signature{Name: "Author A", Email: "authora@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	commit, err := r.CommitObject(hash)
+	require.NoError(t, err)
+
+	facts, err := NewCommitFacts(context.Background(), commit)
+	require.NoError(t, err)
+	assert.Equal(t, hash, facts.Hash)
+	assert.Equal(t, "Add main", facts.Message)
+	assert.Equal(t, object.FileStats{{Name: "main.go", Addition: 3, Deletion: 0}}, facts.Stats, "Stats should count the added lines")
+
+	rebuilt := facts.Commit()
+	assert.Equal(t, commit.Author, rebuilt.Author, "Rebuilt commit should keep the author")
+	assert.Equal(t, 0, rebuilt.NumParents(), "Rebuilt commit should keep the parents")
+}
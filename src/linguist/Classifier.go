@@ -0,0 +1,108 @@
+package linguist
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitattributes"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const attributesFileName = ".gitattributes"
+
+// Classification tells how linguist would treat a file according to the
+// linguist-generated, linguist-vendored and linguist-documentation attributes.
+type Classification struct {
+    Generated     bool
+    Vendored      bool
+    Documentation bool
+}
+
+// IsExcluded reports whether the file should be left out of the language and size statistics.
+func (c Classification) IsExcluded() bool {
+    return c.Generated || c.Vendored || c.Documentation
+}
+
+// Classifier classifies the paths of a tree using all the .gitattributes files it contains.
+// The zero value classifies every path as a regular file.
+type Classifier struct {
+    stack []gitattributes.MatchAttribute // in increasing priority
+}
+
+// NewClassifier reads every .gitattributes file of the tree.
+func NewClassifier(tree *object.Tree) (Classifier, error) {
+    var attributesFiles []*object.File
+    err := tree.Files().ForEach(func(f *object.File) error {
+        if path.Base(f.Name) == attributesFileName {
+            attributesFiles = append(attributesFiles, f)
+        }
+        return nil
+    })
+    if err != nil {
+        return Classifier{}, err
+    }
+
+    // The matcher expects the patterns in increasing priority: the root file first, then the nested ones.
+    sort.SliceStable(attributesFiles, func(i, j int) bool {
+        return strings.Count(attributesFiles[i].Name, "/") < strings.Count(attributesFiles[j].Name, "/")
+    })
+
+    var stack []gitattributes.MatchAttribute
+    for _, f := range attributesFiles {
+        reader, err := f.Reader()
+        if err != nil {
+            return Classifier{}, err
+        }
+        domain := splitPath(path.Dir(f.Name))
+        attributes, err := gitattributes.ReadAttributes(reader, domain, len(domain) == 0)
+        reader.Close()
+        if err != nil {
+            return Classifier{}, err
+        }
+        stack = append(stack, attributes...)
+    }
+
+    return Classifier{stack: stack}, nil
+}
+
+// Classify returns the classification of the slash separated path.
+func (c Classifier) Classify(p string) Classification {
+    if len(c.stack) == 0 {
+        return Classification{}
+    }
+    parts := splitPath(p)
+    return Classification{
+        Generated:     c.isSet(parts, "linguist-generated"),
+        Vendored:      c.isSet(parts, "linguist-vendored"),
+        Documentation: c.isSet(parts, "linguist-documentation"),
+    }
+}
+
+// isSet looks for the last rule that mentions the attribute and matches the path.
+func (c Classifier) isSet(parts []string, name string) bool {
+    for i := len(c.stack) - 1; i >= 0; i-- {
+        rule := c.stack[i]
+        if rule.Pattern == nil || !rule.Pattern.Match(parts) {
+            continue
+        }
+        for _, attribute := range rule.Attributes {
+            if attribute.Name() != name {
+                continue
+            }
+            if attribute.IsValueSet() {
+                value := strings.ToLower(attribute.Value())
+                return value == "true" || value == "1"
+            }
+            return attribute.IsSet()
+        }
+    }
+    return false
+}
+
+func splitPath(p string) []string {
+    if p == "." || p == "" {
+        return nil
+    }
+    return strings.Split(p, "/")
+}
@@ -0,0 +1,85 @@
+package linguist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createMockTree commits the given files to an in-memory repository and returns the commit tree.
+func createMockTree(t *testing.T, files map[string]string) *object.Tree {
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	for name, content := range files {
+		require.NoError(t, util.WriteFile(fs, name, []byte(content), 0644))
+		_, err = w.Add(name)
+		require.NoError(t, err)
+	}
+	hash, err := w.Commit("Test commit message", &git.CommitOptions{
+		Author: &object.Signature{Name: "Author A", Email: "authora@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	commit, err := r.CommitObject(hash)
+	require.NoError(t, err)
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+	return tree
+}
+
+func TestClassifier_Classify(t *testing.T) {
+	tree := createMockTree(t, map[string]string{
+		".gitattributes": "*.pb.go linguist-generated\n" +
+			"third_party/** linguist-vendored=true\n" +
+			"docs/** linguist-documentation\n" +
+			"*.min.js text linguist-generated\n" +
+			"*.min.js diff\n",
+		"docs/.gitattributes": "api.md -linguist-documentation\n",
+		"main.go":            "package main",
+	})
+
+	classifier, err := NewClassifier(tree)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		path        string
+		expected    Classification
+		description string
+	}{
+		{"main.go", Classification{}, "Regular file"},
+		{"api/v1/service.pb.go", Classification{Generated: true}, "Generated file in a sub directory"},
+		{"third_party/lib/lib.go", Classification{Vendored: true}, "Vendored file with a value"},
+		{"docs/guide.md", Classification{Documentation: true}, "Documentation file"},
+		{"docs/api.md", Classification{}, "Nested .gitattributes unsets the attribute"},
+		{"web/app.min.js", Classification{Generated: true}, "Later rules without the attribute do not hide it"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, classifier.Classify(tc.path), "Classification of %s", tc.path)
+		})
+	}
+}
+
+func TestClassifier_ZeroValue(t *testing.T) {
+	classifier := Classifier{}
+	assert.False(t, classifier.Classify("api/service.pb.go").IsExcluded(), "Zero value should not exclude any file")
+}
+
+func TestClassification_IsExcluded(t *testing.T) {
+	assert.False(t, Classification{}.IsExcluded(), "Regular file should not be excluded")
+	assert.True(t, Classification{Generated: true}.IsExcluded(), "Generated file should be excluded")
+	assert.True(t, Classification{Vendored: true}.IsExcluded(), "Vendored file should be excluded")
+	assert.True(t, Classification{Documentation: true}.IsExcluded(), "Documentation file should be excluded")
+}
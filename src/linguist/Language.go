@@ -0,0 +1,245 @@
+package linguist
+
+import (
+	"bytes"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// OtherLanguage is reported for the files that could not be classified.
+const OtherLanguage = "Other"
+
+// sniffSize is how much of a file is read to look for a shebang or to resolve an ambiguous extension.
+const sniffSize = 8 * 1024
+
+// Kind tells what a language is used for, like the type of the languages of GitHub's linguist.
+type Kind int
+
+const (
+    KindProgramming Kind = iota // code, including markup and style sheets
+    KindData                    // configuration, lock and data files
+    KindProse                   // documentation
+)
+
+type language struct {
+    name         string
+    kind         Kind
+    extensions   []string
+    filenames    []string
+    interpreters []string
+}
+
+var languages = []language{
+    {name: "Assembly", extensions: []string{".asm", ".s", ".S", ".nasm"}},
+    {name: "Awk", extensions: []string{".awk"}, interpreters: []string{"awk", "gawk", "mawk", "nawk"}},
+    {name: "Batchfile", extensions: []string{".bat", ".cmd"}},
+    {name: "C", extensions: []string{".c"}},
+    {name: "C#", extensions: []string{".cs", ".csx"}},
+    {name: "C++", extensions: []string{".cpp", ".cc", ".cxx", ".c++", ".hpp", ".hh", ".hxx", ".h++", ".ipp", ".tpp"}},
+    {name: "CMake", extensions: []string{".cmake"}, filenames: []string{"CMakeLists.txt"}},
+    {name: "CSS", extensions: []string{".css"}},
+    {name: "Clojure", extensions: []string{".clj", ".cljs", ".cljc", ".edn"}},
+    {name: "CoffeeScript", extensions: []string{".coffee"}},
+    {name: "Dart", extensions: []string{".dart"}},
+    {name: "Dockerfile", extensions: []string{".dockerfile"}, filenames: []string{"Dockerfile", "Containerfile"}},
+    {name: "Elixir", extensions: []string{".ex", ".exs"}, interpreters: []string{"elixir"}},
+    {name: "Emacs Lisp", extensions: []string{".el"}, filenames: []string{".emacs"}},
+    {name: "Erlang", extensions: []string{".erl", ".hrl"}, filenames: []string{"rebar.config"}, interpreters: []string{"escript"}},
+    {name: "F#", extensions: []string{".fs", ".fsi", ".fsx"}},
+    {name: "Fortran", extensions: []string{".f", ".f77", ".f90", ".f95", ".f03", ".for"}},
+    {name: "Go", extensions: []string{".go"}},
+    {name: "Go Checksums", kind: KindData, filenames: []string{"go.sum", "go.work.sum"}},
+    {name: "Go Module", kind: KindData, filenames: []string{"go.mod", "go.work"}},
+    {name: "GraphQL", extensions: []string{".graphql", ".gql"}},
+    {name: "Groovy", extensions: []string{".groovy", ".gradle", ".gvy"}, filenames: []string{"Jenkinsfile"}, interpreters: []string{"groovy"}},
+    {name: "HCL", extensions: []string{".hcl", ".tf", ".tfvars"}},
+    {name: "HTML", extensions: []string{".html", ".htm", ".xhtml"}},
+    {name: "Haskell", extensions: []string{".hs", ".lhs"}, interpreters: []string{"runhaskell"}},
+    {name: "INI", kind: KindData, extensions: []string{".ini", ".cfg"}, filenames: []string{".editorconfig", ".gitconfig"}},
+    {name: "JSON", kind: KindData, extensions: []string{".json", ".jsonc", ".json5"}, filenames: []string{".babelrc", ".eslintrc"}},
+    {name: "Java", extensions: []string{".java"}},
+    {name: "JavaScript", extensions: []string{".js", ".jsx", ".mjs", ".cjs"}, filenames: []string{"Jakefile"}, interpreters: []string{"node", "nodejs"}},
+    {name: "Julia", extensions: []string{".jl"}, interpreters: []string{"julia"}},
+    {name: "Kotlin", extensions: []string{".kt", ".kts"}},
+    {name: "Less", extensions: []string{".less"}},
+    {name: "Lua", extensions: []string{".lua"}, interpreters: []string{"lua", "luajit"}},
+    {name: "Makefile", extensions: []string{".mk", ".mak"}, filenames: []string{"Makefile", "makefile", "GNUmakefile"}, interpreters: []string{"make"}},
+    {name: "Markdown", kind: KindProse, extensions: []string{".md", ".markdown", ".mdx"}},
+    {name: "Nix", extensions: []string{".nix"}},
+    {name: "OCaml", extensions: []string{".ml", ".mli"}, interpreters: []string{"ocaml"}},
+    {name: "Objective-C", extensions: []string{".m"}},
+    {name: "Objective-C++", extensions: []string{".mm"}},
+    {name: "PHP", extensions: []string{".php", ".phtml"}, interpreters: []string{"php"}},
+    {name: "Pascal", extensions: []string{".pas", ".pp", ".dpr"}},
+    {name: "Perl", extensions: []string{".pl", ".pm", ".t"}, interpreters: []string{"perl"}},
+    {name: "PowerShell", extensions: []string{".ps1", ".psm1", ".psd1"}, interpreters: []string{"pwsh"}},
+    {name: "Protocol Buffer", extensions: []string{".proto"}},
+    {name: "Python", extensions: []string{".py", ".pyw", ".pyi"}, filenames: []string{"SConstruct", "SConscript"}, interpreters: []string{"python"}},
+    {name: "R", extensions: []string{".r", ".R"}, interpreters: []string{"Rscript"}},
+    {name: "Ruby", extensions: []string{".rb", ".rake", ".gemspec"}, filenames: []string{"Gemfile", "Rakefile", "Vagrantfile", "Podfile"}, interpreters: []string{"ruby", "jruby"}},
+    {name: "Rust", extensions: []string{".rs"}},
+    {name: "SCSS", extensions: []string{".scss", ".sass"}},
+    {name: "SQL", extensions: []string{".sql"}},
+    {name: "Scala", extensions: []string{".scala", ".sc", ".sbt"}, interpreters: []string{"scala"}},
+    {name: "Shell", extensions: []string{".sh", ".bash", ".zsh", ".ksh", ".fish"}, filenames: []string{".bashrc", ".bash_profile", ".profile", ".zshrc"}, interpreters: []string{"sh", "bash", "zsh", "ksh", "dash", "ash", "fish"}},
+    {name: "Svelte", extensions: []string{".svelte"}},
+    {name: "Swift", extensions: []string{".swift"}},
+    {name: "TOML", kind: KindData, extensions: []string{".toml"}, filenames: []string{"Cargo.lock", "Pipfile"}},
+    {name: "Tcl", extensions: []string{".tcl"}, interpreters: []string{"tclsh", "wish"}},
+    {name: "Text", kind: KindProse, extensions: []string{".txt"}, filenames: []string{"LICENSE", "COPYING", "AUTHORS", "NOTICE", "README", "CHANGELOG"}},
+    {name: "TypeScript", extensions: []string{".ts", ".tsx", ".mts", ".cts"}, interpreters: []string{"deno", "ts-node"}},
+    {name: "Vim Script", extensions: []string{".vim"}, filenames: []string{".vimrc"}},
+    {name: "Visual Basic", extensions: []string{".vb", ".vbs", ".bas"}},
+    {name: "Vue", extensions: []string{".vue"}},
+    {name: "XML", kind: KindData, extensions: []string{".xml", ".xsd", ".xsl", ".svg", ".plist", ".csproj"}},
+    {name: "YAML", kind: KindData, extensions: []string{".yml", ".yaml"}},
+    {name: "Zig", extensions: []string{".zig"}},
+    {name: "reStructuredText", kind: KindProse, extensions: []string{".rst"}},
+}
+
+var languageKinds = make(map[string]Kind)
+var languageByExtension = make(map[string]string)
+var languageByFilename = make(map[string]string)
+var languageByInterpreter = make(map[string]string)
+
+func init() {
+    for _, l := range languages {
+        languageKinds[l.name] = l.kind
+        for _, ext := range l.extensions {
+            languageByExtension[ext] = l.name
+        }
+        for _, filename := range l.filenames {
+            languageByFilename[filename] = l.name
+        }
+        for _, interpreter := range l.interpreters {
+            languageByInterpreter[interpreter] = l.name
+        }
+    }
+}
+
+// LanguageKind returns the kind of a language returned by Detect, OtherLanguage is data.
+func LanguageKind(name string) Kind {
+    if kind, exists := languageKinds[name]; exists {
+        return kind
+    }
+    return KindData
+}
+
+// ambiguousExtensions are shared by several languages and need a look at the content.
+var ambiguousExtensions = map[string]func(content []byte) string{
+    ".h": detectCHeader,
+}
+
+// NeedsContent reports whether Detect needs the beginning of the file to classify it.
+// It is false when the name alone is enough, so callers can avoid reading the blob.
+func NeedsContent(name string) bool {
+    base := path.Base(name)
+    if _, exists := languageByFilename[base]; exists {
+        return false
+    }
+    ext := path.Ext(base)
+    if _, exists := ambiguousExtensions[ext]; exists {
+        return true
+    }
+    if _, exists := languageByExtension[ext]; exists {
+        return false
+    }
+    _, exists := languageByExtension[strings.ToLower(ext)]
+    return !exists
+}
+
+// Detect returns the language of a file from its name and, when the name is not enough,
+// the beginning of its content (a shebang or language specific keywords).
+func Detect(name string, content []byte) string {
+    base := path.Base(name)
+    if l, exists := languageByFilename[base]; exists {
+        return l
+    }
+    if strings.HasPrefix(base, "Dockerfile.") || strings.HasSuffix(base, ".Dockerfile") {
+        return "Dockerfile"
+    }
+
+    ext := path.Ext(base)
+    if detect, exists := ambiguousExtensions[ext]; exists {
+        return detect(content)
+    }
+    if l, exists := languageByExtension[ext]; exists {
+        return l
+    }
+    if l, exists := languageByExtension[strings.ToLower(ext)]; exists {
+        return l
+    }
+
+    if l := detectShebang(content); l != "" {
+        return l
+    }
+    return OtherLanguage
+}
+
+// DetectFile is Detect for a file of a tree. The blob is only read when the name is not enough.
+func DetectFile(f *object.File) (string, error) {
+    if !NeedsContent(f.Name) {
+        return Detect(f.Name, nil), nil
+    }
+    reader, err := f.Reader()
+    if err != nil {
+        return "", err
+    }
+    defer reader.Close()
+
+    content := make([]byte, sniffSize)
+    n, err := io.ReadFull(reader, content)
+    if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+        return "", err
+    }
+    return Detect(f.Name, content[:n]), nil
+}
+
+var interpreterVersion = regexp.MustCompile(`[0-9.]+$`)
+
+// detectShebang returns the language of the interpreter named by a "#!" first line.
+func detectShebang(content []byte) string {
+    if !bytes.HasPrefix(content, []byte("#!")) {
+        return ""
+    }
+    firstLine, _, _ := bytes.Cut(content[2:], []byte("\n"))
+    fields := strings.Fields(string(firstLine))
+    if len(fields) == 0 {
+        return ""
+    }
+
+    interpreter := path.Base(fields[0])
+    if interpreter == "env" {
+        interpreter = ""
+        for _, field := range fields[1:] {
+            // Skip the options of env, e.g. "#!/usr/bin/env -S python3 -u"
+            if !strings.HasPrefix(field, "-") && !strings.Contains(field, "=") {
+                interpreter = path.Base(field)
+                break
+            }
+        }
+    }
+
+    if l, exists := languageByInterpreter[interpreter]; exists {
+        return l
+    }
+    return languageByInterpreter[interpreterVersion.ReplaceAllString(interpreter, "")]
+}
+
+var objectiveCKeywords = regexp.MustCompile(`(?m)^\s*(@interface|@protocol|@property|@end|#import)\b`)
+var cppKeywords = regexp.MustCompile(`(?m)(^\s*(class|namespace|template\s*<|using\s+namespace)\b|std::|\bpublic:|\bprivate:|#include\s*<(iostream|string|vector|memory|map)>)`)
+
+// detectCHeader tells C, C++ and Objective-C headers apart.
+func detectCHeader(content []byte) string {
+    if objectiveCKeywords.Match(content) {
+        return "Objective-C"
+    }
+    if cppKeywords.Match(content) {
+        return "C++"
+    }
+    return "C"
+}
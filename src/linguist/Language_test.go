@@ -0,0 +1,70 @@
+package linguist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		name        string
+		content     string
+		expected    string
+		description string
+	}{
+		{"cmd/root.go", "", "Go", "Known extension"},
+		{"src/App.TSX", "", "TypeScript", "Extension in upper case"},
+		{"Makefile", "", "Makefile", "Well-known file name"},
+		{"build/Dockerfile", "", "Dockerfile", "Well-known file name in a sub directory"},
+		{"Dockerfile.prod", "", "Dockerfile", "Dockerfile with a suffix"},
+		{"go.mod", "", "Go Module", "Well-known file name with an extension"},
+		{"bin/deploy", "#!/bin/bash\nset -e\n", "Shell", "Shebang with an absolute path"},
+		{"bin/tool", "#!/usr/bin/env python3\nimport sys\n", "Python", "Shebang with env and a versioned interpreter"},
+		{"bin/run", "#!/usr/bin/env -S node --no-warnings\n", "JavaScript", "Shebang with env options"},
+		{"bin/unknown", "#!/usr/bin/env frobnicate\n", OtherLanguage, "Shebang with an unknown interpreter"},
+		{"data.bin", "\x00\x01\x02", OtherLanguage, "Unknown extension"},
+		{"include/list.h", "#ifndef LIST_H\nstruct list *list_new(void);\n", "C", "C header"},
+		{"include/list.h", "#pragma once\nnamespace util {\nclass List {};\n}\n", "C++", "C++ header"},
+		{"include/View.h", "#import <UIKit/UIKit.h>\n@interface View : UIView\n@end\n", "Objective-C", "Objective-C header"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Detect(tc.name, []byte(tc.content)), "Language of %s", tc.name)
+		})
+	}
+}
+
+func TestNeedsContent(t *testing.T) {
+	assert.False(t, NeedsContent("main.go"), "Known extension should not need the content")
+	assert.False(t, NeedsContent("Makefile"), "Well-known file name should not need the content")
+	assert.True(t, NeedsContent("include/list.h"), "Ambiguous extension should need the content")
+	assert.True(t, NeedsContent("bin/deploy"), "File without extension should need the content")
+}
+
+func TestDetectFile(t *testing.T) {
+	tree := createMockTree(t, map[string]string{
+		"main.go":    "package main",
+		"bin/deploy": "#!/bin/sh\necho deploy\n",
+	})
+
+	testCases := map[string]string{"main.go": "Go", "bin/deploy": "Shell"}
+	for name, expected := range testCases {
+		f, err := tree.File(name)
+		require.NoError(t, err)
+		language, err := DetectFile(f)
+		require.NoError(t, err)
+		assert.Equal(t, expected, language, "Language of %s", name)
+	}
+}
+
+func TestLanguageKind(t *testing.T) {
+	assert.Equal(t, KindProgramming, LanguageKind("Go"))
+	assert.Equal(t, KindProgramming, LanguageKind("HTML"))
+	assert.Equal(t, KindData, LanguageKind("Go Checksums"))
+	assert.Equal(t, KindData, LanguageKind("YAML"))
+	assert.Equal(t, KindProse, LanguageKind("Markdown"))
+	assert.Equal(t, KindData, LanguageKind(OtherLanguage))
+}
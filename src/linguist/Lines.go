@@ -0,0 +1,181 @@
+package linguist
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// binarySniffSize is how much of a file is looked at for NUL bytes, like git does.
+const binarySniffSize = 8000
+
+// LineCounts is the number of code, comment and blank lines of one or more files.
+type LineCounts struct {
+    Code    int
+    Comment int
+    Blank   int
+}
+
+func (c *LineCounts) Add(other LineCounts) {
+    c.Code += other.Code
+    c.Comment += other.Comment
+    c.Blank += other.Blank
+}
+
+type commentSyntax struct {
+    line  []string
+    block [][2]string
+}
+
+var cStyle = commentSyntax{line: []string{"//"}, block: [][2]string{{"/*", "*/"}}}
+var hashStyle = commentSyntax{line: []string{"#"}}
+var markupStyle = commentSyntax{block: [][2]string{{"<!--", "-->"}}}
+
+// commentSyntaxes tells how each language writes comments. Languages missing from
+// this table have no comments: every non blank line is counted as code.
+var commentSyntaxes = map[string]commentSyntax{
+    "Assembly":        {line: []string{";", "#"}},
+    "Awk":             hashStyle,
+    "Batchfile":       {line: []string{"::", "REM ", "rem ", "@REM ", "@rem "}},
+    "C":               cStyle,
+    "C#":              cStyle,
+    "C++":             cStyle,
+    "CMake":           hashStyle,
+    "CSS":             {block: [][2]string{{"/*", "*/"}}},
+    "Clojure":         {line: []string{";"}},
+    "CoffeeScript":    {line: []string{"#"}, block: [][2]string{{"###", "###"}}},
+    "Dart":            cStyle,
+    "Dockerfile":      hashStyle,
+    "Elixir":          hashStyle,
+    "Emacs Lisp":      {line: []string{";"}},
+    "Erlang":          {line: []string{"%"}},
+    "F#":              {line: []string{"//"}, block: [][2]string{{"(*", "*)"}}},
+    "Fortran":         {line: []string{"!"}},
+    "Go":              cStyle,
+    "Go Module":       {line: []string{"//"}},
+    "GraphQL":         hashStyle,
+    "Groovy":          cStyle,
+    "HCL":             {line: []string{"#", "//"}, block: [][2]string{{"/*", "*/"}}},
+    "HTML":            markupStyle,
+    "Haskell":         {line: []string{"--"}, block: [][2]string{{"{-", "-}"}}},
+    "INI":             {line: []string{";", "#"}},
+    "Java":            cStyle,
+    "JavaScript":      cStyle,
+    "Julia":           {line: []string{"#"}, block: [][2]string{{"#=", "=#"}}},
+    "Kotlin":          cStyle,
+    "Less":            cStyle,
+    "Lua":             {line: []string{"--"}, block: [][2]string{{"--[[", "]]"}}},
+    "Makefile":        hashStyle,
+    "Markdown":        markupStyle,
+    "Nix":             {line: []string{"#"}, block: [][2]string{{"/*", "*/"}}},
+    "OCaml":           {block: [][2]string{{"(*", "*)"}}},
+    "Objective-C":     cStyle,
+    "Objective-C++":   cStyle,
+    "PHP":             {line: []string{"//", "#"}, block: [][2]string{{"/*", "*/"}}},
+    "Pascal":          {line: []string{"//"}, block: [][2]string{{"{", "}"}, {"(*", "*)"}}},
+    "Perl":            {line: []string{"#"}, block: [][2]string{{"=pod", "=cut"}, {"=head", "=cut"}}},
+    "PowerShell":      {line: []string{"#"}, block: [][2]string{{"<#", "#>"}}},
+    "Protocol Buffer": cStyle,
+    "Python":          hashStyle,
+    "R":               hashStyle,
+    "Ruby":            {line: []string{"#"}, block: [][2]string{{"=begin", "=end"}}},
+    "Rust":            cStyle,
+    "SCSS":            cStyle,
+    "SQL":             {line: []string{"--"}, block: [][2]string{{"/*", "*/"}}},
+    "Scala":           cStyle,
+    "Shell":           hashStyle,
+    "Svelte":          markupStyle,
+    "Swift":           cStyle,
+    "TOML":            hashStyle,
+    "Tcl":             hashStyle,
+    "TypeScript":      cStyle,
+    "Vim Script":      {line: []string{"\""}},
+    "Visual Basic":    {line: []string{"'", "REM ", "Rem "}},
+    "Vue":             markupStyle,
+    "XML":             markupStyle,
+    "YAML":            hashStyle,
+    "Zig":             {line: []string{"//"}},
+}
+
+// IsBinary reports whether the content looks like a binary file.
+func IsBinary(content []byte) bool {
+    return bytes.IndexByte(content[:min(len(content), binarySniffSize)], 0) >= 0
+}
+
+// CountLines counts the code, comment and blank lines of a file written in the given language.
+// A line holding both code and a comment is counted as code.
+func CountLines(language string, content []byte) LineCounts {
+    syntax := commentSyntaxes[language]
+    var counts LineCounts
+    var blockEnd string // end marker of the block comment we are in, if any
+
+    scanner := bufio.NewScanner(bytes.NewReader(content))
+    scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+    for scanner.Scan() {
+        line := strings.TrimSpace(scanner.Text())
+        if line == "" {
+            counts.Blank++
+            continue
+        }
+
+        hasCode := false
+        for line != "" {
+            if blockEnd != "" {
+                end := strings.Index(line, blockEnd)
+                if end < 0 {
+                    break
+                }
+                line = strings.TrimSpace(line[end+len(blockEnd):])
+                blockEnd = ""
+                continue
+            }
+            if start, end := syntax.findBlockStart(line); start >= 0 {
+                hasCode = hasCode || strings.TrimSpace(line[:start]) != ""
+                line = line[start+len(syntax.block[end][0]):]
+                blockEnd = syntax.block[end][1]
+                continue
+            }
+            if syntax.findLineComment(line) != 0 {
+                hasCode = true
+            }
+            break
+        }
+
+        if hasCode {
+            counts.Code++
+        } else {
+            counts.Comment++
+        }
+    }
+    return counts
+}
+
+// findBlockStart returns the position of the first block comment start in the line
+// that is not inside a line comment, and the index of the block syntax it belongs to.
+func (s commentSyntax) findBlockStart(line string) (int, int) {
+    lineComment := s.findLineComment(line)
+    start, syntaxIndex := -1, -1
+    for i, block := range s.block {
+        position := strings.Index(line, block[0])
+        if position < 0 || (start >= 0 && position >= start) {
+            continue
+        }
+        if lineComment >= 0 && lineComment < position {
+            continue
+        }
+        // a line comment marker that is a prefix of the block marker, like "--" and "--[[", does not hide it
+        start, syntaxIndex = position, i
+    }
+    return start, syntaxIndex
+}
+
+// findLineComment returns the position of the first line comment marker, or -1.
+func (s commentSyntax) findLineComment(line string) int {
+    first := -1
+    for _, marker := range s.line {
+        if position := strings.Index(line, marker); position >= 0 && (first < 0 || position < first) {
+            first = position
+        }
+    }
+    return first
+}
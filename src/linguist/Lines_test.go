@@ -0,0 +1,45 @@
+package linguist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountLines(t *testing.T) {
+	testCases := []struct {
+		language    string
+		content     string
+		expected    LineCounts
+		description string
+	}{
+		{"Go", "package main\n\n// main is the entry point\nfunc main() {\n\tx := 1 // trailing comment\n}\n", LineCounts{Code: 4, Comment: 1, Blank: 1}, "Line comments"},
+		{"Go", "/*\nLicense\n\n*/\npackage main\n", LineCounts{Code: 1, Comment: 3, Blank: 1}, "Block comment over several lines"},
+		{"C", "int a; /* starts\nstill comment */ int b;\n/* one */ /* two */\n", LineCounts{Code: 2, Comment: 1}, "Code around block comments"},
+		{"Go", "url := \"http://example.com\"\n", LineCounts{Code: 1}, "Comment marker after code"},
+		{"Python", "#!/usr/bin/env python3\nimport sys\n\n\n# comment\n", LineCounts{Code: 1, Comment: 2, Blank: 2}, "Hash comments"},
+		{"Lua", "--[[ block\ncomment ]]\n-- line\nprint(1)\n", LineCounts{Code: 1, Comment: 3}, "Block marker starting with the line marker"},
+		{"HTML", "<!-- header -->\n<p>text</p>\n", LineCounts{Code: 1, Comment: 1}, "Markup comments"},
+		{"JSON", "{\n  \"a\": 1\n}\n", LineCounts{Code: 3}, "Language without comments"},
+		{"Go", "", LineCounts{}, "Empty file"},
+		{"Go", "package main", LineCounts{Code: 1}, "No trailing new line"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, CountLines(tc.language, []byte(tc.content)))
+		})
+	}
+}
+
+func TestLineCounts_Add(t *testing.T) {
+	counts := LineCounts{Code: 1, Comment: 2, Blank: 3}
+	counts.Add(LineCounts{Code: 10, Comment: 20, Blank: 30})
+	assert.Equal(t, LineCounts{Code: 11, Comment: 22, Blank: 33}, counts, "Counts should be summed")
+}
+
+func TestIsBinary(t *testing.T) {
+	assert.False(t, IsBinary([]byte("package main\n")), "Text should not be binary")
+	assert.True(t, IsBinary([]byte("\x89PNG\r\n\x1a\n\x00\x00")), "Content with NUL bytes should be binary")
+	assert.False(t, IsBinary(nil), "Empty content should not be binary")
+}
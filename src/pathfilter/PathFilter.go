@@ -0,0 +1,96 @@
+package pathfilter
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// PathFilter decides which paths of the repository are analyzed.
+//
+// Patterns are slash separated globs relative to the repository root. A pattern
+// matches a path when it matches the path itself or one of its parent directories,
+// so "services/billing" and "services/billing/" both select the whole directory.
+// "**" matches any number of directories and a pattern without a slash matches at
+// any depth, like in .gitignore ("*.pb.go", "vendor").
+type PathFilter struct {
+    include [][]string
+    exclude [][]string
+}
+
+func NewPathFilter(include []string, exclude []string) (PathFilter, error) {
+    var f PathFilter
+    var err error
+    if f.include, err = compilePatterns(include); err != nil {
+        return f, err
+    }
+    if f.exclude, err = compilePatterns(exclude); err != nil {
+        return f, err
+    }
+    return f, nil
+}
+
+// IsEmpty reports whether the filter lets every path through.
+func (f PathFilter) IsEmpty() bool {
+    return len(f.include) == 0 && len(f.exclude) == 0
+}
+
+// Match reports whether the path is selected by the include patterns (if any)
+// and not rejected by any of the exclude patterns.
+func (f PathFilter) Match(p string) bool {
+    parts := strings.Split(p, "/")
+    if len(f.include) > 0 && !matchAny(f.include, parts) {
+        return false
+    }
+    return !matchAny(f.exclude, parts)
+}
+
+func compilePatterns(patterns []string) ([][]string, error) {
+    var compiled [][]string
+    for _, pattern := range patterns {
+        trimmed := strings.Trim(strings.TrimSpace(pattern), "/")
+        if trimmed == "" {
+            continue
+        }
+        if _, err := path.Match(trimmed, ""); err != nil {
+            return nil, fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+        }
+        segments := strings.Split(trimmed, "/")
+        if len(segments) == 1 && segments[0] != "**" {
+            segments = []string{"**", segments[0]}
+        }
+        compiled = append(compiled, segments)
+    }
+    return compiled, nil
+}
+
+func matchAny(patterns [][]string, parts []string) bool {
+    for _, pattern := range patterns {
+        if matchPrefix(pattern, parts) {
+            return true
+        }
+    }
+    return false
+}
+
+// matchPrefix reports whether the pattern matches the leading parts of the path.
+func matchPrefix(pattern []string, parts []string) bool {
+    if len(pattern) == 0 {
+        return true
+    }
+    if pattern[0] == "**" {
+        for i := 0; i <= len(parts); i++ {
+            if matchPrefix(pattern[1:], parts[i:]) {
+                return true
+            }
+        }
+        return false
+    }
+    if len(parts) == 0 {
+        return false
+    }
+    if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+        return false
+    }
+    return matchPrefix(pattern[1:], parts[1:])
+}
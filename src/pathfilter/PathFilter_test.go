@@ -0,0 +1,58 @@
+package pathfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathFilter_Match(t *testing.T) {
+	testCases := []struct {
+		include     []string
+		exclude     []string
+		path        string
+		expected    bool
+		description string
+	}{
+		{nil, nil, "main.go", true, "Empty filter matches everything"},
+		{[]string{"services/billing/"}, nil, "services/billing/api/handler.go", true, "Directory with trailing slash"},
+		{[]string{"services/billing"}, nil, "services/billing/main.go", true, "Directory without trailing slash"},
+		{[]string{"services/billing"}, nil, "services/billing-v2/main.go", false, "Directory prefix is not a path prefix"},
+		{[]string{"services/billing"}, nil, "services/users/main.go", false, "Path outside of the included directory"},
+		{[]string{"services/*/api"}, nil, "services/users/api/routes.go", true, "Glob in a directory segment"},
+		{[]string{"src/**/*.go"}, nil, "src/a/b/c.go", true, "Double star matches many directories"},
+		{[]string{"src/**/*.go"}, nil, "src/c.go", true, "Double star matches no directory"},
+		{[]string{"src/**/*.go"}, nil, "src/c.js", false, "Double star with a non matching file"},
+		{nil, []string{"vendor"}, "vendor/github.com/lib/lib.go", false, "Excluded top level directory"},
+		{nil, []string{"vendor"}, "third_party/vendor/lib.go", false, "Pattern without slash matches at any depth"},
+		{nil, []string{"*.pb.go"}, "api/v1/service.pb.go", false, "Excluded file extension at any depth"},
+		{nil, []string{"*.pb.go"}, "api/v1/service.go", true, "File not matching the exclude pattern"},
+		{[]string{"services/billing"}, []string{"services/billing/gen"}, "services/billing/gen/x.go", false, "Exclude wins over include"},
+		{[]string{"services/billing"}, []string{"services/billing/gen"}, "services/billing/x.go", true, "Include with a non matching exclude"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			f, err := NewPathFilter(tc.include, tc.exclude)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, f.Match(tc.path), "Match(%q) should be %v", tc.path, tc.expected)
+		})
+	}
+}
+
+func TestPathFilter_IsEmpty(t *testing.T) {
+	f, err := NewPathFilter(nil, []string{" ", "/"})
+	require.NoError(t, err)
+	assert.True(t, f.IsEmpty(), "Blank patterns should be ignored")
+
+	f, err = NewPathFilter([]string{"src"}, nil)
+	require.NoError(t, err)
+	assert.False(t, f.IsEmpty(), "Filter with an include pattern should not be empty")
+}
+
+func TestNewPathFilter_InvalidPattern(t *testing.T) {
+	_, err := NewPathFilter([]string{"src/[a-"}, nil)
+	assert.Error(t, err, "Should return an error for a malformed pattern")
+	assert.Contains(t, err.Error(), "src/[a-", "Error should contain the pattern")
+}
@@ -0,0 +1,142 @@
+package progress
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pterm/pterm"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// refreshInterval is how often the progress line is redrawn.
+const refreshInterval = 200 * time.Millisecond
+
+const (
+    PhaseFetch     = "Fetching the history"
+    PhaseDiff      = "Diffing the branches"
+    PhaseLog       = "Walking the commits"
+    PhaseFiles     = "Walking the files"
+    PhaseRendering = "Rendering the reports"
+)
+
+// Progress shows what the analysis is doing on a spinner line. The counters are safe for
+// concurrent use, so the workers can report their progress directly.
+type Progress struct {
+    commits    atomic.Int64
+    files      atomic.Int64
+    totalFiles atomic.Int64
+
+    mu         sync.Mutex
+    phase      string
+    phaseStart time.Time
+
+    spinner *pterm.SpinnerPrinter
+    done    chan struct{}
+    stopped sync.WaitGroup
+}
+
+// Start draws the progress line on w, usually os.Stderr so that the report written to
+// stdout is not mixed with it.
+func Start(w io.Writer) *Progress {
+    p := &Progress{phase: PhaseLog, phaseStart: time.Now(), done: make(chan struct{})}
+    p.spinner, _ = pterm.DefaultSpinner.WithWriter(w).WithRemoveWhenDone().Start(p.status(time.Now()))
+
+    p.stopped.Add(1)
+    go func() {
+        defer p.stopped.Done()
+        ticker := time.NewTicker(refreshInterval)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-p.done:
+                return
+            case now := <-ticker.C:
+                p.spinner.UpdateText(p.status(now))
+            }
+        }
+    }()
+    return p
+}
+
+// SetPhase switches to the next step of the analysis.
+func (p *Progress) SetPhase(phase string) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.phase = phase
+    p.phaseStart = time.Now()
+}
+
+// AddCommit counts a walked commit.
+func (p *Progress) AddCommit() {
+    p.commits.Add(1)
+}
+
+// AddFile counts a processed file.
+func (p *Progress) AddFile() {
+    p.files.Add(1)
+}
+
+// SetTotalFiles gives the number of files to process, used to estimate the remaining time.
+func (p *Progress) SetTotalFiles(total int) {
+    p.totalFiles.Store(int64(total))
+}
+
+// Commits returns the number of walked commits.
+func (p *Progress) Commits() int64 {
+    return p.commits.Load()
+}
+
+// Files returns the number of processed files.
+func (p *Progress) Files() int64 {
+    return p.files.Load()
+}
+
+// Stop removes the progress line. It must be called before writing to the same terminal.
+func (p *Progress) Stop() {
+    close(p.done)
+    p.stopped.Wait()
+    p.spinner.Stop()
+}
+
+func (p *Progress) status(now time.Time) string {
+    p.mu.Lock()
+    phase, elapsed := p.phase, now.Sub(p.phaseStart)
+    p.mu.Unlock()
+
+    printer := message.NewPrinter(language.English)
+    commits, files, totalFiles := p.commits.Load(), p.files.Load(), p.totalFiles.Load()
+    switch phase {
+    case PhaseFetch, PhaseDiff:
+        return phase
+    case PhaseLog:
+        status := printer.Sprintf("%s: %d commits", phase, commits)
+        if seconds := elapsed.Seconds(); seconds >= 1 {
+            status += printer.Sprintf(" (%.0f/s)", float64(commits)/seconds)
+        }
+        return status
+    case PhaseFiles:
+        status := printer.Sprintf("%s: %d commits, %d", phase, commits, files)
+        if totalFiles > 0 {
+            status += printer.Sprintf("/%d", totalFiles)
+        }
+        status += " files"
+        if eta, known := estimate(files, totalFiles, elapsed); known {
+            status += ", ETA " + eta.String()
+        }
+        return status
+    default:
+        return printer.Sprintf("%s: %d commits, %d files", phase, commits, files)
+    }
+}
+
+// estimate extrapolates the remaining time from the speed so far.
+func estimate(done int64, total int64, elapsed time.Duration) (time.Duration, bool) {
+    if done == 0 || total <= done || elapsed < time.Second {
+        return 0, false
+    }
+    remaining := time.Duration(float64(elapsed) * float64(total-done) / float64(done))
+    return remaining.Round(time.Second), true
+}
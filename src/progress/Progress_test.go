@@ -0,0 +1,52 @@
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress_Status(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+	p := &Progress{phase: PhaseLog, phaseStart: start}
+	for i := 0; i < 2500; i++ {
+		p.AddCommit()
+	}
+	assert.Equal(t, "Walking the commits: 2,500 commits", p.status(start.Add(500*time.Millisecond)), "Speed should not be shown before a second")
+	assert.Equal(t, "Walking the commits: 2,500 commits (1,250/s)", p.status(start.Add(2*time.Second)))
+
+	p.phase, p.phaseStart = PhaseFiles, start
+	p.SetTotalFiles(400)
+	for i := 0; i < 100; i++ {
+		p.AddFile()
+	}
+	assert.Equal(t, "Walking the files: 2,500 commits, 100/400 files, ETA 30s", p.status(start.Add(10*time.Second)))
+
+	p.phase = PhaseRendering
+	assert.Equal(t, "Rendering the reports: 2,500 commits, 100 files", p.status(start))
+}
+
+func TestEstimate(t *testing.T) {
+	testCases := []struct {
+		done, total int64
+		elapsed     time.Duration
+		expected    time.Duration
+		known       bool
+		description string
+	}{
+		{50, 100, 10 * time.Second, 10 * time.Second, true, "Half done"},
+		{0, 100, 10 * time.Second, 0, false, "Nothing done yet"},
+		{50, 0, 10 * time.Second, 0, false, "Unknown total"},
+		{100, 100, 10 * time.Second, 0, false, "Everything done"},
+		{50, 100, 100 * time.Millisecond, 0, false, "Too early to tell"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			eta, known := estimate(tc.done, tc.total, tc.elapsed)
+			assert.Equal(t, tc.known, known)
+			assert.Equal(t, tc.expected, eta)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package report
+
+// Compare returns a table of the values of the bar chart of two periods side by side, with the
+// percentage change of every label. The labels of the current period come first, then the ones
+// only found in the previous period. The other report types cannot be compared.
+func Compare(previous Report, current Report, previousName string, currentName string) (Report, bool) {
+    if previous.reportType != "bar_chart" || current.reportType != "bar_chart" {
+        return Report{}, false
+    }
+
+    previousValues := make(map[string]int, len(previous.labels))
+    for i, label := range previous.labels {
+        previousValues[label] = previous.data[i].IntValue
+    }
+    currentValues := make(map[string]int, len(current.labels))
+    for i, label := range current.labels {
+        currentValues[label] = current.data[i].IntValue
+    }
+    labels := append([]string{}, current.labels...)
+    for _, label := range previous.labels {
+        if _, exists := currentValues[label]; !exists {
+            labels = append(labels, label)
+        }
+    }
+
+    rows := make([][]Value, len(labels))
+    for i, label := range labels {
+        rows[i] = []Value{IntValue(previousValues[label]), IntValue(currentValues[label]), ChangeValue(previousValues[label], currentValues[label])}
+    }
+
+    r := Report{}
+    r.SetLabels(labels)
+    r.SetColumns([]Column{{Name: previousName, Type: TypeInt}, {Name: currentName, Type: TypeInt}, {Name: "Change", Type: TypeChange}})
+    r.SetRows(rows)
+    r.SetTitle(current.title)
+    r.SetReportType("table")
+    return r, true
+}
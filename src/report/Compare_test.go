@@ -0,0 +1,38 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	previous := Report{}
+	previous.SetTitle("Commits per developer")
+	previous.SetReportType("bar_chart")
+	previous.SetLabels([]string{"Alice", "Carol", "Bob"})
+	previous.SetData([]Data{{IntValue: 8, IsInt: true}, {IntValue: 3, IsInt: true}, {IntValue: 2, IsInt: true}})
+
+	current := Report{}
+	current.SetTitle("Commits per developer")
+	current.SetReportType("bar_chart")
+	current.SetLabels([]string{"Alice", "Dave", "Bob"})
+	current.SetData([]Data{{IntValue: 10, IsInt: true}, {IntValue: 4, IsInt: true}, {IntValue: 2, IsInt: true}})
+
+	compared, ok := Compare(previous, current, "April", "May")
+	assert.True(t, ok)
+	assert.Equal(t, "Commits per developer", compared.GetTitle())
+	assert.Equal(t, "table", compared.GetReportType())
+	assert.Equal(t, []string{"Alice", "Dave", "Bob", "Carol"}, compared.GetLabels(), "The labels only found in the previous period should come last")
+	assert.Equal(t, []Column{{Name: "April", Type: TypeInt}, {Name: "May", Type: TypeInt}, {Name: "Change", Type: TypeChange}}, compared.GetColumns())
+	assert.Equal(t, [][]Value{
+		{IntValue(8), IntValue(10), ChangeValue(8, 10)},
+		{IntValue(0), IntValue(4), StringValue("new")},
+		{IntValue(2), IntValue(2), ChangeValue(2, 2)},
+		{IntValue(3), IntValue(0), ChangeValue(3, 0)},
+	}, compared.GetRows())
+
+	current.SetReportType("line_chart")
+	_, ok = Compare(previous, current, "April", "May")
+	assert.False(t, ok, "Only the bar charts can be compared")
+}
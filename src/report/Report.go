@@ -6,11 +6,36 @@ type Data struct {
     IsInt       bool
 }
 
+// Value returns the data as a typed value.
+func (d Data) Value() Value {
+    if d.IsInt {
+        return IntValue(d.IntValue)
+    }
+    return StringValue(d.StringValue)
+}
+
+// Column describes a column of a table, its values are formatted after its type and unit.
+type Column struct {
+    Name string
+    Type Type
+    Unit string // e.g. "lines" or "KB", printed after the numbers
+}
+
+// Series is a named list of values, one per label, for the charts drawing several lines or areas.
+type Series struct {
+    Name   string
+    Values []int
+}
+
 type Report struct {
     data []Data
+    series []Series
+    columns []Column
+    rows [][]Value // one row per label, one value per column
     labels []string
     title string
     reportType string
+    note string
 }
 
 func (r *Report) SetTitle(t string) {
@@ -21,6 +46,21 @@ func (r *Report) SetData(d []Data) {
     r.data = d
 }
 
+// SetSeries sets the series of line_chart and stacked_area reports, used instead of the data.
+func (r *Report) SetSeries(s []Series) {
+    r.series = s
+}
+
+// SetColumns sets the columns of table reports with several values per label, used with SetRows
+// instead of the data.
+func (r *Report) SetColumns(c []Column) {
+    r.columns = c
+}
+
+func (r *Report) SetRows(rows [][]Value) {
+    r.rows = rows
+}
+
 func (r *Report) SetLabels(l []string) {
     r.labels = l
 }
@@ -29,6 +69,11 @@ func (r *Report) SetReportType(l string) {
     r.reportType = l
 }
 
+// SetNote attaches a warning to the report, e.g. when it only covers a part of the history.
+func (r *Report) SetNote(n string) {
+    r.note = n
+}
+
 func (r Report) GetTitle() string {
     return r.title
 }
@@ -37,6 +82,48 @@ func (r Report) GetData() []Data {
     return r.data
 }
 
+func (r Report) GetSeries() []Series {
+    return r.series
+}
+
+// ChartSeries returns the series of the report, or a single series named after the title
+// holding the int values of the data when the report has no series.
+func (r Report) ChartSeries() []Series {
+    if len(r.series) > 0 {
+        return r.series
+    }
+    values := make([]int, len(r.data))
+    for i, d := range r.data {
+        values[i] = d.IntValue
+    }
+    return []Series{{Name: r.title, Values: values}}
+}
+
+func (r Report) GetColumns() []Column {
+    return r.columns
+}
+
+func (r Report) GetRows() [][]Value {
+    return r.rows
+}
+
+// Table returns the columns and rows of the report, or a single unnamed column holding the
+// data when the report has no columns.
+func (r Report) Table() ([]Column, [][]Value) {
+    if len(r.columns) > 0 {
+        return r.columns, r.rows
+    }
+    column := Column{Type: TypeString}
+    rows := make([][]Value, len(r.data))
+    for i, d := range r.data {
+        rows[i] = []Value{d.Value()}
+        if i == 0 && d.IsInt {
+            column.Type = TypeInt
+        }
+    }
+    return []Column{column}, rows
+}
+
 func (r Report) GetLabels() []string {
     return r.labels
 }
@@ -44,3 +131,7 @@ func (r Report) GetLabels() []string {
 func (r Report) GetReportType() string {
     return r.reportType
 }
+
+func (r Report) GetNote() string {
+    return r.note
+}
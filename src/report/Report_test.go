@@ -117,3 +117,48 @@ func TestReport_GetReportType(t *testing.T) {
 	retrievedReportType := report.GetReportType()
 	assert.Equal(t, reportType, retrievedReportType, "GetReportType should return the correct report type")
 }
+
+func TestReport_SetNote(t *testing.T) {
+	// Test for Report.SetNote and Report.GetNote
+	report := Report{}
+	assert.Equal(t, "", report.GetNote(), "Reports should have no note by default")
+
+	report.SetNote("Incomplete")
+	assert.Equal(t, "Incomplete", report.GetNote(), "GetNote should return the note")
+}
+
+func TestReport_ChartSeries(t *testing.T) {
+	report := Report{}
+	report.SetTitle("Commits")
+	report.SetData([]Data{{IntValue: 3, IsInt: true}, {IntValue: 5, IsInt: true}})
+	assert.Empty(t, report.GetSeries(), "Series should be empty by default")
+	assert.Equal(t, []Series{{Name: "Commits", Values: []int{3, 5}}}, report.ChartSeries(), "The data should be the single series")
+
+	series := []Series{{Name: "Alice", Values: []int{1, 2}}, {Name: "Bob", Values: []int{2, 3}}}
+	report.SetSeries(series)
+	assert.Equal(t, series, report.GetSeries(), "Series should be set correctly")
+	assert.Equal(t, series, report.ChartSeries(), "The series should be used instead of the data")
+}
+
+func TestReport_Table(t *testing.T) {
+	report := Report{}
+	report.SetData([]Data{{IntValue: 3, IsInt: true}, {IntValue: 5, IsInt: true}})
+	columns, rows := report.Table()
+	assert.Equal(t, []Column{{Type: TypeInt}}, columns, "The data should be a single unnamed column")
+	assert.Equal(t, [][]Value{{IntValue(3)}, {IntValue(5)}}, rows, "The data should be the rows")
+
+	report.SetData([]Data{{StringValue: "main"}})
+	columns, rows = report.Table()
+	assert.Equal(t, []Column{{Type: TypeString}}, columns)
+	assert.Equal(t, [][]Value{{StringValue("main")}}, rows)
+
+	columns = []Column{{Name: "Commits", Type: TypeInt}, {Name: "Added", Type: TypeInt, Unit: "lines"}}
+	rows = [][]Value{{IntValue(3), IntValue(120)}}
+	report.SetColumns(columns)
+	report.SetRows(rows)
+	assert.Equal(t, columns, report.GetColumns(), "Columns should be set correctly")
+	assert.Equal(t, rows, report.GetRows(), "Rows should be set correctly")
+	gotColumns, gotRows := report.Table()
+	assert.Equal(t, columns, gotColumns, "The columns should be used instead of the data")
+	assert.Equal(t, rows, gotRows)
+}
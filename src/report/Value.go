@@ -0,0 +1,116 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Type is the type of the values of a column, telling the printers how to format them.
+type Type int
+
+const (
+    TypeString Type = iota
+    TypeInt
+    TypeFloat
+    TypeDuration
+    TypeDate
+    TypePercentage
+    TypeChange // percentage change, printed with its sign
+)
+
+// Value is a typed cell of a table, only the field matching its type is set.
+type Value struct {
+    Type     Type
+    Int      int
+    Float    float64 // floats, percentages and changes, 12.5 for 12.5%
+    Duration time.Duration
+    Date     time.Time
+    String   string
+}
+
+func IntValue(n int) Value {
+    return Value{Type: TypeInt, Int: n}
+}
+
+func FloatValue(f float64) Value {
+    return Value{Type: TypeFloat, Float: f}
+}
+
+func DurationValue(d time.Duration) Value {
+    return Value{Type: TypeDuration, Duration: d}
+}
+
+func DateValue(t time.Time) Value {
+    return Value{Type: TypeDate, Date: t}
+}
+
+// PercentageValue takes the percentage, 12.5 for 12.5%.
+func PercentageValue(p float64) Value {
+    return Value{Type: TypePercentage, Float: p}
+}
+
+// ChangeValue returns the percentage change from previous to current, or "new" when there was
+// nothing before.
+func ChangeValue(previous int, current int) Value {
+    if previous == 0 && current != 0 {
+        return StringValue("new")
+    }
+    change := 0.0
+    if previous != 0 {
+        change = float64(current-previous) * 100 / float64(previous)
+    }
+    return Value{Type: TypeChange, Float: change}
+}
+
+func StringValue(s string) Value {
+    return Value{Type: TypeString, String: s}
+}
+
+// Format prints the value, followed by the unit for the numbers, e.g. "1200 lines", "3.25 KB",
+// "12.5%", "+12.5%", "3d 4h" or "2024-01-31". Floats are rounded to 2 decimals and percentages to 1.
+func (v Value) Format(unit string) string {
+    var s string
+    switch v.Type {
+    case TypeInt:
+        s = strconv.Itoa(v.Int)
+    case TypeFloat:
+        s = strconv.FormatFloat(math.Round(v.Float*100)/100, 'f', -1, 64)
+    case TypePercentage:
+        return strconv.FormatFloat(math.Round(v.Float*10)/10, 'f', -1, 64) + "%"
+    case TypeChange:
+        change := math.Round(v.Float*10) / 10
+        if change > 0 {
+            return "+" + strconv.FormatFloat(change, 'f', -1, 64) + "%"
+        }
+        return strconv.FormatFloat(change, 'f', -1, 64) + "%"
+    case TypeDuration:
+        return FormatDuration(v.Duration)
+    case TypeDate:
+        if v.Date.IsZero() {
+            return ""
+        }
+        return v.Date.Format(time.DateOnly)
+    default:
+        return v.String
+    }
+    if unit != "" {
+        s += " " + unit
+    }
+    return s
+}
+
+// FormatDuration prints a duration with its two most significant units, e.g. "3d 4h".
+func FormatDuration(d time.Duration) string {
+    switch {
+    case d < time.Minute:
+        return fmt.Sprintf("%ds", int(d.Seconds()))
+    case d < time.Hour:
+        return fmt.Sprintf("%dm", int(d.Minutes()))
+    case d < 24*time.Hour:
+        return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+    default:
+        return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+    }
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValue_Format(t *testing.T) {
+	testCases := []struct {
+		value       Value
+		unit        string
+		expected    string
+		description string
+	}{
+		{IntValue(1200), "", "1200", "Int"},
+		{IntValue(1200), "lines", "1200 lines", "Int with a unit"},
+		{FloatValue(3.14159), "KB", "3.14 KB", "Float rounded with a unit"},
+		{FloatValue(2), "", "2", "Round float"},
+		{PercentageValue(12.345), "", "12.3%", "Percentage"},
+		{PercentageValue(50), "commits", "50%", "Percentage ignores the unit"},
+		{DurationValue(76*time.Hour + 10*time.Minute), "", "3d 4h", "Duration"},
+		{DateValue(time.Date(2024, time.January, 31, 15, 0, 0, 0, time.UTC)), "", "2024-01-31", "Date"},
+		{DateValue(time.Time{}), "", "", "Zero date"},
+		{StringValue("main"), "lines", "main", "String ignores the unit"},
+		{ChangeValue(8, 10), "", "+25%", "Increase"},
+		{ChangeValue(3, 0), "", "-100%", "Decrease"},
+		{ChangeValue(3, 4), "", "+33.3%", "Rounded change"},
+		{ChangeValue(2, 2), "", "0%", "No change"},
+		{ChangeValue(0, 0), "", "0%", "Nothing in both periods"},
+		{ChangeValue(0, 5), "", "new", "Nothing before"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.value.Format(tc.unit))
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "45s", FormatDuration(45*time.Second))
+	assert.Equal(t, "12m", FormatDuration(12*time.Minute+30*time.Second))
+	assert.Equal(t, "5h 3m", FormatDuration(5*time.Hour+3*time.Minute))
+	assert.Equal(t, "3d 4h", FormatDuration(76*time.Hour+10*time.Minute))
+}
@@ -0,0 +1,136 @@
+package reportgenerator
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+)
+
+// maxStackedDevelopers limits the series of the commits per developer chart, the others are summed up.
+const maxStackedDevelopers = 5
+
+// ActiveContributorsReportGenerator counts the distinct developers committing in every period,
+// to see whether the project is growing or shrinking, and the commits of the periods.
+type ActiveContributorsReportGenerator struct {
+    Identity Identity // signature giving the date of the commits
+    Granularity Granularity
+    CoAuthorCredit CoAuthorCredit // co-authors are active contributors too unless CoAuthorCreditAuthorOnly
+    Authors *Authors // resolves the co-authors through the .mailmap file
+
+    CommitsMap map[time.Time]int // beginning of the period => commits
+    ContributorsMap map[time.Time]map[string]float64 // beginning of the period => developer name => credited commits
+
+    mu sync.Mutex
+}
+
+func (r *ActiveContributorsReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    period := r.Granularity.Start(r.Identity.Signature(c).When.Local())
+    credits := r.CoAuthorCredit.Credits(c, a, r.Authors)
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.CommitsMap == nil {
+        r.CommitsMap = make(map[time.Time]int)
+        r.ContributorsMap = make(map[time.Time]map[string]float64)
+    }
+    if r.ContributorsMap[period] == nil {
+        r.ContributorsMap[period] = make(map[string]float64)
+    }
+    r.CommitsMap[period]++
+    for _, credit := range credits {
+        r.ContributorsMap[period][credit.Author.Name] += credit.Share
+    }
+}
+
+// GetReport returns the active contributors per period, see GetReports for the commits.
+func (rg *ActiveContributorsReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return rg.seriesReport("Active contributors", func(period time.Time) int { return len(rg.ContributorsMap[period]) })
+}
+
+// GetReports returns the active contributors, the commits and the commits of the most
+// active developers per period.
+func (rg *ActiveContributorsReportGenerator) GetReports() []report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return []report.Report{
+        rg.seriesReport("Active contributors", func(period time.Time) int { return len(rg.ContributorsMap[period]) }),
+        rg.seriesReport("Commits", func(period time.Time) int { return rg.CommitsMap[period] }),
+        rg.developersReport(),
+    }
+}
+
+// seriesReport returns a line chart of value over every period of the history, the quiet ones too.
+func (rg *ActiveContributorsReportGenerator) seriesReport(title string, value func(period time.Time) int) report.Report {
+    labels, periods := periodLabels(rg.Granularity, rg.CommitsMap)
+    var data []report.Data
+    for _, period := range periods {
+        data = append(data, report.Data{IsInt: true, IntValue: value(period)})
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetData(data)
+    r.SetTitle(title + " per " + rg.Granularity.String())
+    r.SetReportType("line_chart")
+    return r
+}
+
+// developersReport returns the commits per period stacked by developer, the most active
+// developers get their own series and the others share one. With CoAuthorCreditFull, a
+// commit is stacked once per credited developer.
+func (rg *ActiveContributorsReportGenerator) developersReport() report.Report {
+    totals := make(map[string]float64)
+    for _, developers := range rg.ContributorsMap {
+        for name, commits := range developers {
+            totals[name] += commits
+        }
+    }
+    names := make([]string, 0, len(totals))
+    for name := range totals {
+        names = append(names, name)
+    }
+    sort.Slice(names, func(i, j int) bool {
+        if totals[names[i]] != totals[names[j]] {
+            return totals[names[i]] > totals[names[j]]
+        }
+        return names[i] < names[j]
+    })
+    others := len(names) > maxStackedDevelopers
+    names = names[:min(len(names), maxStackedDevelopers)]
+
+    labels, periods := periodLabels(rg.Granularity, rg.CommitsMap)
+    series := make([]report.Series, len(names))
+    for i, name := range names {
+        series[i].Name = name
+        for _, period := range periods {
+            series[i].Values = append(series[i].Values, int(math.Round(rg.ContributorsMap[period][name])))
+        }
+    }
+    if others {
+        othersSeries := report.Series{Name: "Others"}
+        for _, period := range periods {
+            commits := 0.0
+            for name, credited := range rg.ContributorsMap[period] {
+                if !slices.Contains(names, name) {
+                    commits += credited
+                }
+            }
+            othersSeries.Values = append(othersSeries.Values, int(math.Round(commits)))
+        }
+        series = append(series, othersSeries)
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetSeries(series)
+    r.SetTitle("Commits per " + rg.Granularity.String() + " by developer")
+    r.SetReportType("stacked_area")
+    return r
+}
@@ -0,0 +1,96 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k1-end/git-reports/src/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActiveContributorsReportGenerator_GetReports(t *testing.T) {
+	generator := ActiveContributorsReportGenerator{}
+	alice := Author{Name: "Alice"}
+	bob := Author{Name: "Bob"}
+	day := func(month time.Month, date int) time.Time {
+		return time.Date(2024, month, date, 12, 0, 0, 0, time.Local)
+	}
+
+	generator.LogIterationStep(createMockCommit("Alice", "alice@example.com", day(time.January, 2)), alice)
+	generator.LogIterationStep(createMockCommit("Alice", "alice@example.com", day(time.January, 5)), alice)
+	generator.LogIterationStep(createMockCommit("Bob", "bob@example.com", day(time.January, 9)), bob)
+	generator.LogIterationStep(createMockCommit("Bob", "bob@example.com", day(time.March, 1)), bob)
+
+	reports := generator.GetReports()
+	require.Len(t, reports, 3)
+
+	contributors := reports[0]
+	assert.Equal(t, "Active contributors per month", contributors.GetTitle())
+	assert.Equal(t, "line_chart", contributors.GetReportType())
+	assert.Equal(t, []string{"2024-01", "2024-02", "2024-03"}, contributors.GetLabels())
+	assert.Equal(t, []int{2, 0, 1}, []int{contributors.GetData()[0].IntValue, contributors.GetData()[1].IntValue, contributors.GetData()[2].IntValue})
+	assert.Equal(t, contributors, generator.GetReport())
+
+	commits := reports[1]
+	assert.Equal(t, "Commits per month", commits.GetTitle())
+	assert.Equal(t, []int{3, 0, 1}, []int{commits.GetData()[0].IntValue, commits.GetData()[1].IntValue, commits.GetData()[2].IntValue})
+
+	developers := reports[2]
+	assert.Equal(t, "Commits per month by developer", developers.GetTitle())
+	assert.Equal(t, "stacked_area", developers.GetReportType())
+	assert.Equal(t, []string{"2024-01", "2024-02", "2024-03"}, developers.GetLabels())
+	assert.Equal(t, []report.Series{
+		{Name: "Alice", Values: []int{2, 0, 0}},
+		{Name: "Bob", Values: []int{1, 0, 1}},
+	}, developers.GetSeries())
+}
+
+func TestActiveContributorsReportGenerator_Others(t *testing.T) {
+	generator := ActiveContributorsReportGenerator{}
+	commitTime := time.Date(2024, time.January, 2, 12, 0, 0, 0, time.Local)
+	for _, name := range []string{"A", "A", "B", "B", "C", "C", "D", "D", "E", "E", "F", "G"} {
+		generator.LogIterationStep(createMockCommit(name, name+"@example.com", commitTime), Author{Name: name})
+	}
+
+	series := generator.GetReports()[2].GetSeries()
+	require.Len(t, series, maxStackedDevelopers+1)
+	assert.Equal(t, "E", series[maxStackedDevelopers-1].Name)
+	assert.Equal(t, report.Series{Name: "Others", Values: []int{2}}, series[maxStackedDevelopers], "F and G share the last series")
+}
+
+func TestActiveContributorsReportGenerator_Granularity(t *testing.T) {
+	generator := ActiveContributorsReportGenerator{Granularity: GranularityQuarter}
+	generator.LogIterationStep(createMockCommit("Alice", "alice@example.com", time.Date(2024, time.February, 1, 12, 0, 0, 0, time.Local)), Author{Name: "Alice"})
+	generator.LogIterationStep(createMockCommit("Bob", "bob@example.com", time.Date(2024, time.March, 1, 12, 0, 0, 0, time.Local)), Author{Name: "Bob"})
+
+	r := generator.GetReport()
+	assert.Equal(t, "Active contributors per quarter", r.GetTitle())
+	assert.Equal(t, []string{"2024 Q1"}, r.GetLabels())
+	assert.Equal(t, 2, r.GetData()[0].IntValue)
+}
+
+func TestActiveContributorsReportGenerator_Empty(t *testing.T) {
+	generator := ActiveContributorsReportGenerator{}
+	assert.Empty(t, generator.GetReport().GetLabels())
+}
+
+func TestActiveContributorsReportGenerator_CoAuthorCredit(t *testing.T) {
+	authorA := Author{Name: "Author A", Emails: map[string]bool{"authora@example.com": true}}
+	paired := createMockCoAuthoredCommit("Pair\n\nCo-authored-by: Author B <authorb@example.com>\n")
+
+	full := ActiveContributorsReportGenerator{CoAuthorCredit: CoAuthorCreditFull}
+	full.LogIterationStep(paired, authorA)
+	reports := full.GetReports()
+	assert.Equal(t, 2, reports[0].GetData()[0].IntValue, "Co-authors should be active contributors")
+	assert.Equal(t, 1, reports[1].GetData()[0].IntValue, "The commit should be counted once")
+	assert.Equal(t, []report.Series{
+		{Name: "Author A", Values: []int{1}},
+		{Name: "Author B", Values: []int{1}},
+	}, reports[2].GetSeries())
+
+	fractional := ActiveContributorsReportGenerator{CoAuthorCredit: CoAuthorCreditFractional}
+	fractional.LogIterationStep(paired, authorA)
+	fractional.LogIterationStep(createMockCoAuthoredCommit("Solo"), authorA)
+	assert.Equal(t, map[string]float64{"Author A": 1.5, "Author B": 0.5}, fractional.ContributorsMap[fractional.Granularity.Start(paired.Author.When.Local())])
+}
@@ -0,0 +1,131 @@
+package reportgenerator
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// maxLandingCommitters limits the rows listing who landed the work of other developers.
+const maxLandingCommitters = 10
+
+// AuthorCommitterReportGenerator compares who wrote the commits with who committed them.
+type AuthorCommitterReportGenerator struct {
+    Authors *Authors // resolves both signatures through the .mailmap file
+
+    CommitsNo int
+    AsWrittenNo int // committed by their author when written
+    RewrittenNo int // committed by their author later: rebase, amend, cherry-pick
+    LandedByOthersNo int
+    LandingLatencies []time.Duration // from authored to committed, for the commits landed by others
+    LandedByMap map[string]int // committer name => commits of others they landed
+
+    landedAuthors map[string]map[string]bool // committer name => names of the authors they landed
+    mu sync.Mutex
+}
+
+func (r *AuthorCommitterReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.Authors == nil {
+        r.Authors = NewAuthors(nil)
+    }
+    if r.LandedByMap == nil {
+        r.LandedByMap = make(map[string]int)
+    }
+    if r.landedAuthors == nil {
+        r.landedAuthors = make(map[string]map[string]bool)
+    }
+
+    author := r.Authors.Resolve(c.Author)
+    committer := r.Authors.Resolve(c.Committer)
+    r.CommitsNo++
+    switch {
+    case author != committer:
+        r.LandedByOthersNo++
+        r.LandingLatencies = append(r.LandingLatencies, max(c.Committer.When.Sub(c.Author.When), 0))
+        r.LandedByMap[committer.Name]++
+        if r.landedAuthors[committer.Name] == nil {
+            r.landedAuthors[committer.Name] = make(map[string]bool)
+        }
+        r.landedAuthors[committer.Name][author.Name] = true
+    case !c.Committer.When.Equal(c.Author.When):
+        r.RewrittenNo++
+    default:
+        r.AsWrittenNo++
+    }
+}
+
+func (rg *AuthorCommitterReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    p := message.NewPrinter(language.English)
+    share := func(n int) report.Data {
+        return report.Data{IsInt: false, StringValue: p.Sprintf("%d (%d%%)", n, percentage(n, rg.CommitsNo))}
+    }
+
+    labels := []string{
+        "Number of commits",
+        "Committed by their author",
+        "Rewritten by their author (rebase, amend, cherry-pick)",
+        "Landed by another developer",
+    }
+    data := []report.Data{
+        {IsInt: false, StringValue: p.Sprintf("%d", rg.CommitsNo)},
+        share(rg.AsWrittenNo),
+        share(rg.RewrittenNo),
+        share(rg.LandedByOthersNo),
+    }
+
+    if len(rg.LandingLatencies) > 0 {
+        latencies := append([]time.Duration(nil), rg.LandingLatencies...)
+        sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+        labels = append(labels, "Median time to land", "90th percentile time to land")
+        data = append(data,
+            report.Data{IsInt: false, StringValue: report.FormatDuration(latencies[len(latencies)/2])},
+            report.Data{IsInt: false, StringValue: report.FormatDuration(latencies[len(latencies)*9/10])},
+        )
+    }
+
+    committers := make([]string, 0, len(rg.LandedByMap))
+    for name := range rg.LandedByMap {
+        committers = append(committers, name)
+    }
+    sort.Slice(committers, func(i, j int) bool {
+        if rg.LandedByMap[committers[i]] != rg.LandedByMap[committers[j]] {
+            return rg.LandedByMap[committers[i]] > rg.LandedByMap[committers[j]]
+        }
+        return committers[i] < committers[j]
+    })
+    for _, name := range committers[:min(len(committers), maxLandingCommitters)] {
+        labels = append(labels, "Landed by "+name)
+        data = append(data, report.Data{IsInt: false, StringValue: p.Sprintf("%d commits from %d authors", rg.LandedByMap[name], len(rg.landedAuthors[name]))})
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetData(data)
+    r.SetTitle("Authors vs Committers")
+    r.SetReportType("table")
+    return r
+}
+
+// share returns n as an unrounded percentage of total, for the percentage columns.
+func share(n int, total int) float64 {
+    if total == 0 {
+        return 0
+    }
+    return float64(n) * 100 / float64(total)
+}
+
+func percentage(n int, total int) int {
+    if total == 0 {
+        return 0
+    }
+    return (n*100 + total/2) / total
+}
@@ -0,0 +1,80 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockCommittedCommit(author string, committer string, authored time.Time, committed time.Time) *object.Commit {
+	commit := createMockCommit(author, author+"@example.com", authored)
+	commit.Committer = object.Signature{Name: committer, Email: committer + "@example.com", When: committed}
+	return commit
+}
+
+func TestAuthorCommitterReportGenerator_LogIterationStep(t *testing.T) {
+	generator := AuthorCommitterReportGenerator{
+		Authors: NewAuthors([]Author{{Name: "Maintainer", Emails: map[string]bool{"maintainer@example.com": true, "maintainer-bot@example.com": true}}}),
+	}
+	authored := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+
+	generator.LogIterationStep(createMockCommittedCommit("alice", "alice", authored, authored), Author{})
+	generator.LogIterationStep(createMockCommittedCommit("alice", "alice", authored, authored.Add(time.Hour)), Author{})
+	generator.LogIterationStep(createMockCommittedCommit("bob", "maintainer", authored, authored.Add(2*time.Hour)), Author{})
+	generator.LogIterationStep(createMockCommittedCommit("alice", "maintainer-bot", authored, authored.Add(26*time.Hour)), Author{})
+	// Mailmap aliases of the same developer are not someone else
+	generator.LogIterationStep(createMockCommittedCommit("maintainer", "maintainer-bot", authored, authored), Author{})
+
+	assert.Equal(t, 5, generator.CommitsNo)
+	assert.Equal(t, 2, generator.AsWrittenNo, "Commits committed when written should be counted")
+	assert.Equal(t, 1, generator.RewrittenNo, "Commits committed later by their author should be counted as rewritten")
+	assert.Equal(t, 2, generator.LandedByOthersNo, "Commits committed by another developer should be counted as landed")
+	assert.Equal(t, map[string]int{"Maintainer": 2}, generator.LandedByMap, "Committers should be resolved through the mailmap")
+	assert.Equal(t, []time.Duration{2 * time.Hour, 26 * time.Hour}, generator.LandingLatencies)
+}
+
+func TestAuthorCommitterReportGenerator_GetReport(t *testing.T) {
+	generator := AuthorCommitterReportGenerator{}
+	authored := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+	generator.LogIterationStep(createMockCommittedCommit("alice", "alice", authored, authored), Author{})
+	generator.LogIterationStep(createMockCommittedCommit("bob", "carol", authored, authored.Add(90*time.Minute)), Author{})
+	generator.LogIterationStep(createMockCommittedCommit("dave", "carol", authored, authored.Add(50*time.Hour)), Author{})
+	generator.LogIterationStep(createMockCommittedCommit("bob", "erin", authored, authored.Add(30*time.Second)), Author{})
+
+	r := generator.GetReport()
+
+	assert.Equal(t, "Authors vs Committers", r.GetTitle())
+	assert.Equal(t, "table", r.GetReportType())
+	assert.Equal(t, []string{
+		"Number of commits",
+		"Committed by their author",
+		"Rewritten by their author (rebase, amend, cherry-pick)",
+		"Landed by another developer",
+		"Median time to land",
+		"90th percentile time to land",
+		"Landed by carol",
+		"Landed by erin",
+	}, r.GetLabels())
+	assert.Equal(t, []report.Data{
+		{IsInt: false, StringValue: "4"},
+		{IsInt: false, StringValue: "1 (25%)"},
+		{IsInt: false, StringValue: "0 (0%)"},
+		{IsInt: false, StringValue: "3 (75%)"},
+		{IsInt: false, StringValue: "1h 30m"},
+		{IsInt: false, StringValue: "2d 2h"},
+		{IsInt: false, StringValue: "2 commits from 2 authors"},
+		{IsInt: false, StringValue: "1 commits from 1 authors"},
+	}, r.GetData())
+}
+
+func TestAuthorCommitterReportGenerator_GetReport_ZeroValues(t *testing.T) {
+	generator := AuthorCommitterReportGenerator{}
+
+	r := generator.GetReport()
+
+	assert.Len(t, r.GetLabels(), 4, "Latencies should not be reported without landed commits")
+	assert.Equal(t, report.Data{IsInt: false, StringValue: "0 (0%)"}, r.GetData()[1])
+}
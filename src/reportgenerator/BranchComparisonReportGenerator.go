@@ -0,0 +1,198 @@
+package reportgenerator
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+)
+
+// maxDivergedFiles limits the files changed on both sides listed in the branch comparison.
+const maxDivergedFiles = 50
+
+// BranchComparisonReportGenerator compares two refs from their merge base: the commits only found on
+// each side, the developers behind them and the files changed on both sides since the merge base.
+type BranchComparisonReportGenerator struct {
+    Refs [2]string // names of the compared refs
+    MergeBase *object.Commit // nil when the histories are unrelated
+    Identity Identity // signature giving the date of the merge base
+    Now time.Time // to measure the age of the divergence, time.Now() when zero
+    FileStats [2]object.FileStats // changes of every side since the merge base
+
+    CommitsMap [2]map[string]int // side => developer name => commits only found on that side
+
+    mu sync.Mutex
+}
+
+// branchSide adds the commits of one side of the comparison.
+type branchSide struct {
+    generator *BranchComparisonReportGenerator
+    side int
+}
+
+// Side returns the generator of the commits only found on the first (0) or second (1) ref.
+func (r *BranchComparisonReportGenerator) Side(side int) LogIterationStepper {
+    return branchSide{generator: r, side: side}
+}
+
+func (s branchSide) LogIterationStep(c *object.Commit, a Author)  {
+    s.generator.mu.Lock()
+    defer s.generator.mu.Unlock()
+    if s.generator.CommitsMap[s.side] == nil {
+        s.generator.CommitsMap[s.side] = make(map[string]int)
+    }
+    s.generator.CommitsMap[s.side][a.Name]++
+}
+
+// GetReport returns the merge base of the refs, see GetReports for the details.
+func (rg *BranchComparisonReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return rg.divergenceReport()
+}
+
+// GetReports returns the merge base of the refs, what every side changed since then, the commits
+// per developer of every side and the files changed on both sides.
+func (rg *BranchComparisonReportGenerator) GetReports() []report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return []report.Report{rg.divergenceReport(), rg.sidesReport(), rg.developersReport(), rg.filesReport()}
+}
+
+func (rg *BranchComparisonReportGenerator) columns() []report.Column {
+    return []report.Column{{Name: rg.Refs[0], Type: report.TypeInt}, {Name: rg.Refs[1], Type: report.TypeInt}}
+}
+
+func (rg *BranchComparisonReportGenerator) divergenceReport() report.Report {
+    r := report.Report{}
+    r.SetTitle("Divergence of " + rg.Refs[0] + " and " + rg.Refs[1])
+    r.SetReportType("table")
+    if rg.MergeBase == nil {
+        r.SetLabels([]string{"Merge base"})
+        r.SetData([]report.Data{{StringValue: "None, the histories are unrelated"}})
+        return r
+    }
+
+    now := rg.Now
+    if now.IsZero() {
+        now = time.Now()
+    }
+    when := rg.Identity.Signature(rg.MergeBase).When
+    r.SetLabels([]string{"Merge base", "Diverged on", "Diverged for", "Files changed on both sides"})
+    r.SetData([]report.Data{
+        {StringValue: rg.MergeBase.Hash.String()[:7]},
+        {StringValue: report.DateValue(when).Format("")},
+        {StringValue: report.DurationValue(now.Sub(when)).Format("")},
+        {IsInt: true, IntValue: len(changedOnBothSides(rg.FileStats))},
+    })
+    return r
+}
+
+func (rg *BranchComparisonReportGenerator) sidesReport() report.Report {
+    var commits, added, deleted [2]int
+    for side := range rg.CommitsMap {
+        for _, n := range rg.CommitsMap[side] {
+            commits[side] += n
+        }
+        for _, file := range rg.FileStats[side] {
+            added[side] += file.Addition
+            deleted[side] += file.Deletion
+        }
+    }
+
+    r := report.Report{}
+    r.SetLabels([]string{"Commits only on this side", "Developers", "Files changed since the merge base", "Lines added", "Lines deleted"})
+    r.SetColumns(rg.columns())
+    r.SetRows([][]report.Value{
+        {report.IntValue(commits[0]), report.IntValue(commits[1])},
+        {report.IntValue(len(rg.CommitsMap[0])), report.IntValue(len(rg.CommitsMap[1]))},
+        {report.IntValue(len(rg.FileStats[0])), report.IntValue(len(rg.FileStats[1]))},
+        {report.IntValue(added[0]), report.IntValue(added[1])},
+        {report.IntValue(deleted[0]), report.IntValue(deleted[1])},
+    })
+    r.SetTitle("Changes of every side since the merge base")
+    r.SetReportType("table")
+    return r
+}
+
+func (rg *BranchComparisonReportGenerator) developersReport() report.Report {
+    totals := make(map[string]int)
+    for side := range rg.CommitsMap {
+        for name, n := range rg.CommitsMap[side] {
+            totals[name] += n
+        }
+    }
+    names := make([]string, 0, len(totals))
+    for name := range totals {
+        names = append(names, name)
+    }
+    sort.Slice(names, func(i, j int) bool {
+        if totals[names[i]] != totals[names[j]] {
+            return totals[names[i]] > totals[names[j]]
+        }
+        return names[i] < names[j]
+    })
+
+    rows := make([][]report.Value, len(names))
+    for i, name := range names {
+        rows[i] = []report.Value{report.IntValue(rg.CommitsMap[0][name]), report.IntValue(rg.CommitsMap[1][name])}
+    }
+
+    r := report.Report{}
+    r.SetLabels(names)
+    r.SetColumns(rg.columns())
+    r.SetRows(rows)
+    r.SetTitle("Commits only on each side per developer")
+    r.SetReportType("table")
+    return r
+}
+
+func (rg *BranchComparisonReportGenerator) filesReport() report.Report {
+    changes := changedOnBothSides(rg.FileStats)
+    files := make([]string, 0, len(changes))
+    for file := range changes {
+        files = append(files, file)
+    }
+    sort.Slice(files, func(i, j int) bool {
+        total := func(file string) int { return changes[file][0] + changes[file][1] }
+        if total(files[i]) != total(files[j]) {
+            return total(files[i]) > total(files[j])
+        }
+        return files[i] < files[j]
+    })
+    files = files[:min(len(files), maxDivergedFiles)]
+
+    rows := make([][]report.Value, len(files))
+    for i, file := range files {
+        rows[i] = []report.Value{report.IntValue(changes[file][0]), report.IntValue(changes[file][1])}
+    }
+
+    r := report.Report{}
+    r.SetLabels(files)
+    columns := rg.columns()
+    for i := range columns {
+        columns[i].Unit = "lines"
+    }
+    r.SetColumns(columns)
+    r.SetRows(rows)
+    r.SetTitle("Files changed on both sides")
+    r.SetReportType("table")
+    return r
+}
+
+// changedOnBothSides returns the lines changed on every side of the files changed on both sides.
+func changedOnBothSides(stats [2]object.FileStats) map[string][2]int {
+    first := make(map[string]int)
+    for _, file := range stats[0] {
+        first[file.Name] += file.Addition + file.Deletion
+    }
+    changes := make(map[string][2]int)
+    for _, file := range stats[1] {
+        if lines, exists := first[file.Name]; exists {
+            changes[file.Name] = [2]int{lines, changes[file.Name][1] + file.Addition + file.Deletion}
+        }
+    }
+    return changes
+}
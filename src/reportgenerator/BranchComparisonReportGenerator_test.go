@@ -0,0 +1,70 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranchComparisonReportGenerator_GetReports(t *testing.T) {
+	mergeBase := createMockCommit("Alice", "alice@example.com", time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC))
+	generator := BranchComparisonReportGenerator{
+		Refs:      [2]string{"main", "release"},
+		MergeBase: mergeBase,
+		Now:       time.Date(2024, time.March, 1, 18, 0, 0, 0, time.UTC),
+		FileStats: [2]object.FileStats{
+			{{Name: "a.go", Addition: 10, Deletion: 2}, {Name: "b.go", Addition: 5}, {Name: "c.go", Addition: 1, Deletion: 1}},
+			{{Name: "a.go", Addition: 1}, {Name: "c.go", Deletion: 30}},
+		},
+	}
+	commit := createMockCommit("Alice", "alice@example.com", time.Now())
+	generator.Side(0).LogIterationStep(commit, Author{Name: "Alice"})
+	generator.Side(0).LogIterationStep(commit, Author{Name: "Alice"})
+	generator.Side(0).LogIterationStep(commit, Author{Name: "Bob"})
+	generator.Side(1).LogIterationStep(commit, Author{Name: "Carol"})
+
+	reports := generator.GetReports()
+	require.Len(t, reports, 4)
+
+	divergence := reports[0]
+	assert.Equal(t, "Divergence of main and release", divergence.GetTitle())
+	assert.Equal(t, divergence, generator.GetReport())
+	assert.Equal(t, []string{"Merge base", "Diverged on", "Diverged for", "Files changed on both sides"}, divergence.GetLabels())
+	assert.Equal(t, mergeBase.Hash.String()[:7], divergence.GetData()[0].StringValue)
+	assert.Equal(t, "2024-01-10", divergence.GetData()[1].StringValue)
+	assert.Equal(t, "51d 6h", divergence.GetData()[2].StringValue)
+	assert.Equal(t, 2, divergence.GetData()[3].IntValue)
+
+	sides := reports[1]
+	assert.Equal(t, []report.Column{{Name: "main", Type: report.TypeInt}, {Name: "release", Type: report.TypeInt}}, sides.GetColumns())
+	assert.Equal(t, [][]report.Value{
+		{report.IntValue(3), report.IntValue(1)},
+		{report.IntValue(2), report.IntValue(1)},
+		{report.IntValue(3), report.IntValue(2)},
+		{report.IntValue(16), report.IntValue(1)},
+		{report.IntValue(3), report.IntValue(30)},
+	}, sides.GetRows())
+
+	developers := reports[2]
+	assert.Equal(t, []string{"Alice", "Bob", "Carol"}, developers.GetLabels())
+	assert.Equal(t, []report.Value{report.IntValue(0), report.IntValue(1)}, developers.GetRows()[2])
+
+	files := reports[3]
+	assert.Equal(t, "Files changed on both sides", files.GetTitle())
+	assert.Equal(t, []string{"c.go", "a.go"}, files.GetLabels(), "The files should be sorted by lines changed")
+	assert.Equal(t, [][]report.Value{{report.IntValue(2), report.IntValue(30)}, {report.IntValue(12), report.IntValue(1)}}, files.GetRows())
+	assert.Equal(t, "lines", files.GetColumns()[0].Unit)
+}
+
+func TestBranchComparisonReportGenerator_Unrelated(t *testing.T) {
+	generator := BranchComparisonReportGenerator{Refs: [2]string{"main", "gh-pages"}}
+
+	divergence := generator.GetReport()
+	assert.Equal(t, []string{"Merge base"}, divergence.GetLabels())
+	assert.Equal(t, "None, the histories are unrelated", divergence.GetData()[0].StringValue)
+	assert.Empty(t, generator.GetReports()[3].GetLabels())
+}
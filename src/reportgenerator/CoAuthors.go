@@ -0,0 +1,64 @@
+package reportgenerator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// CoAuthorCredit tells how the developers listed in the Co-authored-by trailers of a commit are credited.
+type CoAuthorCredit string
+
+const (
+    CoAuthorCreditAuthorOnly CoAuthorCredit = "author-only" // only the author gets the commit
+    CoAuthorCreditFull       CoAuthorCredit = "full"        // every co-author gets the whole commit
+    CoAuthorCreditFractional CoAuthorCredit = "fractional"  // the commit is split between the author and the co-authors
+)
+
+// Credit is the share of a commit given to a developer.
+type Credit struct {
+    Author Author
+    Share  float64
+}
+
+var coAuthorTrailer = regexp.MustCompile(`(?mi)^[ \t]*co-authored-by:[ \t]*(.*?)[ \t]*<([^>\n]+)>[ \t]*$`)
+
+// CoAuthors returns the signatures of the Co-authored-by trailers of a commit message.
+func CoAuthors(message string) []object.Signature {
+    var signatures []object.Signature
+    for _, match := range coAuthorTrailer.FindAllStringSubmatch(message, -1) {
+        signatures = append(signatures, object.Signature{Name: match[1], Email: strings.TrimSpace(match[2])})
+    }
+    return signatures
+}
+
+// Credits returns the developers credited for the commit of author a. The co-authors are
+// resolved through authors like the author was, and the ones resolving to the author or
+// listed twice are only credited once. The zero value is CoAuthorCreditAuthorOnly.
+func (credit CoAuthorCredit) Credits(c *object.Commit, a Author, authors *Authors) []Credit {
+    developers := []Author{a}
+    if credit == CoAuthorCreditFull || credit == CoAuthorCreditFractional {
+        if authors == nil {
+            authors = NewAuthors(nil)
+        }
+        seen := map[string]bool{a.Name: true}
+        for _, signature := range CoAuthors(c.Message) {
+            coAuthor := authors.Resolve(signature)
+            if !seen[coAuthor.Name] {
+                seen[coAuthor.Name] = true
+                developers = append(developers, *coAuthor)
+            }
+        }
+    }
+
+    share := 1.0
+    if credit == CoAuthorCreditFractional {
+        share = 1 / float64(len(developers))
+    }
+    credits := make([]Credit, len(developers))
+    for i, developer := range developers {
+        credits[i] = Credit{Author: developer, Share: share}
+    }
+    return credits
+}
@@ -0,0 +1,54 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockCoAuthoredCommit(message string) *object.Commit {
+	commit := createMockCommit("Author A", "authora@example.com", time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC))
+	commit.Message = message
+	return commit
+}
+
+func TestCoAuthors(t *testing.T) {
+	message := "Add the parser\n\nLong description.\n\n" +
+		"Co-authored-by: Author B <authorb@example.com>\n" +
+		"co-authored-by:Author C<authorc@example.com>  \n" +
+		"Signed-off-by: Author A <authora@example.com>\n" +
+		"Co-authored-by: missing email\n"
+
+	assert.Equal(t, []object.Signature{
+		{Name: "Author B", Email: "authorb@example.com"},
+		{Name: "Author C", Email: "authorc@example.com"},
+	}, CoAuthors(message), "Co-authored-by trailers should be parsed case insensitively")
+	assert.Empty(t, CoAuthors("Fix the build"), "Messages without trailers should have no co-authors")
+}
+
+func TestCoAuthorCredit_Credits(t *testing.T) {
+	authors := NewAuthors([]Author{{Name: "Author B", Emails: map[string]bool{"authorb@example.com": true, "b@personal.example.com": true}}})
+	authorA := Author{Name: "Author A", Emails: map[string]bool{"authora@example.com": true}}
+	commit := createMockCoAuthoredCommit("Pair on the parser\n\n" +
+		"Co-authored-by: B <b@personal.example.com>\n" +
+		"Co-authored-by: Author B <authorb@example.com>\n" +
+		"Co-authored-by: Author A <authora@example.com>\n")
+
+	credits := CoAuthorCreditAuthorOnly.Credits(commit, authorA, authors)
+	assert.Equal(t, []Credit{{Author: authorA, Share: 1}}, credits, "Only the author should be credited")
+	assert.Equal(t, credits, CoAuthorCredit("").Credits(commit, authorA, authors), "Zero value should credit the author only")
+
+	credits = CoAuthorCreditFull.Credits(commit, authorA, authors)
+	if assert.Len(t, credits, 2, "Co-authors should be resolved through the mailmap and credited once") {
+		assert.Equal(t, "Author B", credits[1].Author.Name, "Mailmap name should be used")
+		assert.Equal(t, 1.0, credits[1].Share, "Full credit should give the whole commit")
+	}
+
+	credits = CoAuthorCreditFractional.Credits(commit, authorA, authors)
+	if assert.Len(t, credits, 2) {
+		assert.Equal(t, 0.5, credits[0].Share, "Fractional credit should split the commit")
+		assert.Equal(t, 0.5, credits[1].Share, "Fractional credit should split the commit")
+	}
+}
@@ -0,0 +1,91 @@
+package reportgenerator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+)
+
+// CodeGrowthReportGenerator follows the lines added and deleted per period and the size of the
+// code base they add up to, counted from the first analyzed commit. Merge commits are ignored,
+// the commits of the merged branch already count their lines.
+type CodeGrowthReportGenerator struct {
+    Identity Identity // signature giving the date of the commits
+    Granularity Granularity
+
+    AddedMap map[time.Time]int // beginning of the period => lines added
+    DeletedMap map[time.Time]int
+
+    mu sync.Mutex
+}
+
+func (r *CodeGrowthReportGenerator) StatsIterationStep(c *object.Commit, a Author, stats object.FileStats)  {
+    if c.NumParents() > 1 {
+        return
+    }
+    added, deleted := 0, 0
+    for _, file := range stats {
+        added += file.Addition
+        deleted += file.Deletion
+    }
+    period := r.Granularity.Start(r.Identity.Signature(c).When.Local())
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.AddedMap == nil {
+        r.AddedMap = make(map[time.Time]int)
+        r.DeletedMap = make(map[time.Time]int)
+    }
+    r.AddedMap[period] += added
+    r.DeletedMap[period] += deleted
+}
+
+// GetReport returns the lines of code at the end of every period, see GetReports for the changes.
+func (rg *CodeGrowthReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return rg.growthReport()
+}
+
+// GetReports returns the lines of code at the end of every period and the lines added and deleted.
+func (rg *CodeGrowthReportGenerator) GetReports() []report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return []report.Report{rg.growthReport(), rg.changesReport()}
+}
+
+func (rg *CodeGrowthReportGenerator) growthReport() report.Report {
+    labels, periods := periodLabels(rg.Granularity, rg.AddedMap)
+    var data []report.Data
+    lines := 0
+    for _, period := range periods {
+        lines += rg.AddedMap[period] - rg.DeletedMap[period]
+        data = append(data, report.Data{IsInt: true, IntValue: lines})
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetData(data)
+    r.SetTitle("Lines of code per " + rg.Granularity.String())
+    r.SetReportType("line_chart")
+    return r
+}
+
+func (rg *CodeGrowthReportGenerator) changesReport() report.Report {
+    labels, periods := periodLabels(rg.Granularity, rg.AddedMap)
+    added := report.Series{Name: "Lines added"}
+    deleted := report.Series{Name: "Lines deleted"}
+    for _, period := range periods {
+        added.Values = append(added.Values, rg.AddedMap[period])
+        deleted.Values = append(deleted.Values, rg.DeletedMap[period])
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetSeries([]report.Series{added, deleted})
+    r.SetTitle("Lines added and deleted per " + rg.Granularity.String())
+    r.SetReportType("line_chart")
+    return r
+}
@@ -0,0 +1,44 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeGrowthReportGenerator_GetReports(t *testing.T) {
+	generator := CodeGrowthReportGenerator{}
+	alice := Author{Name: "Alice"}
+	month := func(month time.Month) *object.Commit {
+		return createMockCommit("Alice", "alice@example.com", time.Date(2024, month, 10, 12, 0, 0, 0, time.Local))
+	}
+
+	generator.StatsIterationStep(month(time.January), alice, object.FileStats{{Name: "a.go", Addition: 100}, {Name: "b.go", Addition: 20}})
+	generator.StatsIterationStep(month(time.January), alice, object.FileStats{{Name: "a.go", Addition: 10, Deletion: 30}})
+	generator.StatsIterationStep(month(time.March), alice, object.FileStats{{Name: "b.go", Deletion: 20}})
+	merge := month(time.March)
+	merge.ParentHashes = []plumbing.Hash{plumbing.NewHash("1"), plumbing.NewHash("2")}
+	generator.StatsIterationStep(merge, alice, object.FileStats{{Name: "a.go", Addition: 500}})
+
+	reports := generator.GetReports()
+	require.Len(t, reports, 2)
+
+	growth := reports[0]
+	assert.Equal(t, "Lines of code per month", growth.GetTitle())
+	assert.Equal(t, "line_chart", growth.GetReportType())
+	assert.Equal(t, []string{"2024-01", "2024-02", "2024-03"}, growth.GetLabels())
+	assert.Equal(t, []report.Series{{Name: "Lines of code per month", Values: []int{100, 100, 80}}}, growth.ChartSeries())
+	assert.Equal(t, growth, generator.GetReport())
+
+	changes := reports[1]
+	assert.Equal(t, "Lines added and deleted per month", changes.GetTitle())
+	assert.Equal(t, []report.Series{
+		{Name: "Lines added", Values: []int{130, 0, 0}},
+		{Name: "Lines deleted", Values: []int{30, 0, 20}},
+	}, changes.GetSeries())
+}
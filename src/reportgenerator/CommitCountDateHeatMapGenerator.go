@@ -3,6 +3,7 @@ package reportgenerator
 import (
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -11,11 +12,16 @@ import (
 
 type CommitCountDateHeatMapGenerator struct {
     CommitsMap map[string]int
+    Identity Identity // signature giving the date of the commits
+
+    mu sync.Mutex
 }
 
-func (r CommitCountDateHeatMapGenerator) LogIterationStep(c *object.Commit, a Author)  {
-    year, month, date := c.Author.When.Local().Date()
+func (r *CommitCountDateHeatMapGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    year, month, date := r.Identity.Signature(c).When.Local().Date()
     key := fmt.Sprintf("%d-%d-%d", year, month, date)
+    r.mu.Lock()
+    defer r.mu.Unlock()
     _, exists := r.CommitsMap[key]
     if !exists {
         r.CommitsMap[key] = 1
@@ -24,7 +30,9 @@ func (r CommitCountDateHeatMapGenerator) LogIterationStep(c *object.Commit, a Au
     }
 }
 
-func (rg CommitCountDateHeatMapGenerator) GetReport() report.Report {
+func (rg *CommitCountDateHeatMapGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
     labels := make([]string, 0, len(rg.CommitsMap))
 
     for k := range rg.CommitsMap {
@@ -0,0 +1,224 @@
+package reportgenerator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+)
+
+// maxMessageQualityAuthors limits the rows of the per author table.
+const maxMessageQualityAuthors = 20
+
+// subjectLengthBucket is the width of the bars of the subject length histogram.
+const subjectLengthBucket = 10
+
+// maxSubjectLength is where the histogram stops, longer subjects share the last bar.
+const maxSubjectLength = 100
+
+// MessageStats are the message hygiene counters of a set of commits.
+type MessageStats struct {
+    Commits int
+    SubjectLengthSum int
+    WithBody int
+    Imperative int
+    WorkInProgress int // WIP, fixup!, squash! and amend! commits
+    TrailingPunctuation int
+}
+
+func (s *MessageStats) add(m MessageMetrics) {
+    s.Commits++
+    s.SubjectLengthSum += m.SubjectLength
+    if m.HasBody {
+        s.WithBody++
+    }
+    if m.Imperative {
+        s.Imperative++
+    }
+    if m.WorkInProgress {
+        s.WorkInProgress++
+    }
+    if m.TrailingPunctuation {
+        s.TrailingPunctuation++
+    }
+}
+
+// MessageMetrics describe a single commit message.
+type MessageMetrics struct {
+    SubjectLength int
+    HasBody bool
+    Imperative bool
+    WorkInProgress bool
+    TrailingPunctuation bool
+}
+
+var workInProgress = regexp.MustCompile(`(?i)^(fixup|squash|amend)! |\bwip\b`)
+var conventionalPrefix = regexp.MustCompile(`^[A-Za-z][\w-]*(\([^()]*\))?!?: `)
+
+// nonImperativeExceptions are words that look like a past tense, a gerund or a third person
+// but are fine at the start of an imperative subject.
+var nonImperativeExceptions = map[string]bool{
+    "bring": true, "embed": true, "exceed": true, "feed": true, "need": true, "proceed": true, "seed": true,
+    "shred": true, "speed": true, "string": true, "succeed": true, "access": true, "bypass": true, "compress": true,
+    "discuss": true, "dismiss": true, "focus": true, "pass": true, "process": true, "address": true, "express": true,
+}
+
+// MeasureMessage computes the metrics of a commit message.
+func MeasureMessage(msg string) MessageMetrics {
+    msg = strings.TrimSpace(msg)
+    subject, body, _ := strings.Cut(msg, "\n")
+    subject = strings.TrimSpace(subject)
+    return MessageMetrics{
+        SubjectLength: utf8.RuneCountInString(subject),
+        HasBody: strings.TrimSpace(body) != "",
+        Imperative: isImperative(subject),
+        WorkInProgress: workInProgress.MatchString(subject),
+        TrailingPunctuation: strings.HasSuffix(subject, ".") || strings.HasSuffix(subject, ",") || strings.HasSuffix(subject, ";") || strings.HasSuffix(subject, ":"),
+    }
+}
+
+// isImperative guesses whether the subject starts with a verb in the imperative mood,
+// like "Add" and unlike "Added", "Adding" or "Adds". The Conventional Commits prefix is skipped.
+func isImperative(subject string) bool {
+    fields := strings.Fields(conventionalPrefix.ReplaceAllString(subject, ""))
+    if len(fields) == 0 {
+        return false
+    }
+    word := strings.ToLower(strings.Trim(fields[0], `"'.,:;!?`))
+    if word == "" || nonImperativeExceptions[word] {
+        return word != ""
+    }
+    if word == "wip" || strings.HasSuffix(word, "ed") || strings.HasSuffix(word, "ing") {
+        return false
+    }
+    if strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is") {
+        return false
+    }
+    return true
+}
+
+// CommitMessageQualityReportGenerator reports on the hygiene of the commit messages per author.
+// Merge commits are ignored, their message is written by git. The co-authors are not credited,
+// the message is the author's.
+type CommitMessageQualityReportGenerator struct {
+    StatsPerDevMap map[string]*MessageStats
+    SubjectLengthsMap map[int]int // bucket index => commits
+
+    mu sync.Mutex
+}
+
+func (r *CommitMessageQualityReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    if c.NumParents() > 1 {
+        return
+    }
+    metrics := MeasureMessage(c.Message)
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.StatsPerDevMap == nil {
+        r.StatsPerDevMap = make(map[string]*MessageStats)
+        r.SubjectLengthsMap = make(map[int]int)
+    }
+    if r.StatsPerDevMap[a.Name] == nil {
+        r.StatsPerDevMap[a.Name] = &MessageStats{}
+    }
+    r.StatsPerDevMap[a.Name].add(metrics)
+    r.SubjectLengthsMap[min(metrics.SubjectLength, maxSubjectLength+1)/subjectLengthBucket]++
+}
+
+// GetReport returns the message metrics per author, see GetReports for the subject length histogram.
+func (rg *CommitMessageQualityReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return rg.tableReport()
+}
+
+// GetReports returns the message metrics per author and the subject length histogram.
+func (rg *CommitMessageQualityReportGenerator) GetReports() []report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return []report.Report{rg.tableReport(), rg.histogramReport()}
+}
+
+func (rg *CommitMessageQualityReportGenerator) tableReport() report.Report {
+    names := make([]string, 0, len(rg.StatsPerDevMap))
+    var total MessageStats
+    for name, stats := range rg.StatsPerDevMap {
+        names = append(names, name)
+        total.Commits += stats.Commits
+        total.SubjectLengthSum += stats.SubjectLengthSum
+        total.WithBody += stats.WithBody
+        total.Imperative += stats.Imperative
+        total.WorkInProgress += stats.WorkInProgress
+        total.TrailingPunctuation += stats.TrailingPunctuation
+    }
+    sort.Slice(names, func(i, j int) bool {
+        if rg.StatsPerDevMap[names[i]].Commits != rg.StatsPerDevMap[names[j]].Commits {
+            return rg.StatsPerDevMap[names[i]].Commits > rg.StatsPerDevMap[names[j]].Commits
+        }
+        return names[i] < names[j]
+    })
+    names = names[:min(len(names), maxMessageQualityAuthors)]
+
+    labels := []string{"All developers"}
+    rows := [][]report.Value{messageStatsRow(total)}
+    for _, name := range names {
+        labels = append(labels, name)
+        rows = append(rows, messageStatsRow(*rg.StatsPerDevMap[name]))
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetColumns([]report.Column{
+        {Name: "Commits", Type: report.TypeInt},
+        {Name: "Chars per subject", Type: report.TypeInt},
+        {Name: "With a body", Type: report.TypePercentage},
+        {Name: "Imperative", Type: report.TypePercentage},
+        {Name: "WIP/fixup/squash", Type: report.TypeInt},
+        {Name: "Trailing punctuation", Type: report.TypeInt},
+    })
+    r.SetRows(rows)
+    r.SetTitle("Commit message quality")
+    r.SetReportType("table")
+    return r
+}
+
+func messageStatsRow(s MessageStats) []report.Value {
+    averageLength := 0
+    if s.Commits > 0 {
+        averageLength = s.SubjectLengthSum / s.Commits
+    }
+    return []report.Value{
+        report.IntValue(s.Commits),
+        report.IntValue(averageLength),
+        report.PercentageValue(share(s.WithBody, s.Commits)),
+        report.PercentageValue(share(s.Imperative, s.Commits)),
+        report.IntValue(s.WorkInProgress),
+        report.IntValue(s.TrailingPunctuation),
+    }
+}
+
+func (rg *CommitMessageQualityReportGenerator) histogramReport() report.Report {
+    var labels []string
+    var data []report.Data
+    for bucket := 0; bucket <= maxSubjectLength/subjectLengthBucket; bucket++ {
+        if bucket == maxSubjectLength/subjectLengthBucket {
+            labels = append(labels, fmt.Sprintf("%d+", maxSubjectLength))
+        } else {
+            labels = append(labels, fmt.Sprintf("%d-%d", bucket*subjectLengthBucket, (bucket+1)*subjectLengthBucket-1))
+        }
+        data = append(data, report.Data{IsInt: true, IntValue: rg.SubjectLengthsMap[bucket]})
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetData(data)
+    r.SetTitle("Commit subject length (characters)")
+    r.SetReportType("bar_chart")
+    return r
+}
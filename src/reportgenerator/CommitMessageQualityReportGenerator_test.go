@@ -0,0 +1,99 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/k1-end/git-reports/src/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasureMessage(t *testing.T) {
+	testCases := []struct {
+		message     string
+		expected    MessageMetrics
+		description string
+	}{
+		{"Add the parser", MessageMetrics{SubjectLength: 14, Imperative: true}, "Imperative subject"},
+		{"Added the parser.", MessageMetrics{SubjectLength: 17, TrailingPunctuation: true}, "Past tense with a period"},
+		{"Adds the parser\n\nIt reads the config.", MessageMetrics{SubjectLength: 15, HasBody: true}, "Third person with a body"},
+		{"Fixing tests\n\n", MessageMetrics{SubjectLength: 12}, "Gerund with an empty body"},
+		{"feat(cli): add flags", MessageMetrics{SubjectLength: 20, Imperative: true}, "Conventional prefix is skipped"},
+		{"fixup! Add the parser", MessageMetrics{SubjectLength: 21, Imperative: true, WorkInProgress: true}, "Fixup commit"},
+		{"WIP on the parser", MessageMetrics{SubjectLength: 17, WorkInProgress: true}, "WIP commit"},
+		{"Process the queue", MessageMetrics{SubjectLength: 17, Imperative: true}, "Exception ending in ss"},
+		{"Embed the assets", MessageMetrics{SubjectLength: 16, Imperative: true}, "Exception ending in ed"},
+		{"Wipe the cache", MessageMetrics{SubjectLength: 14, Imperative: true}, "wip inside a word"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MeasureMessage(tc.message), "Metrics of %q", tc.message)
+		})
+	}
+}
+
+func TestCommitMessageQualityReportGenerator_LogIterationStep(t *testing.T) {
+	generator := CommitMessageQualityReportGenerator{}
+	now := time.Now()
+	alice := Author{Name: "Alice"}
+	bob := Author{Name: "Bob"}
+
+	generator.LogIterationStep(createMockMessageCommit("Add the parser\n\nDetails", now), alice)
+	generator.LogIterationStep(createMockMessageCommit("Fixed a typo.", now), alice)
+	generator.LogIterationStep(createMockMessageCommit("WIP", now), bob)
+	merge := createMockMessageCommit("Merge branch 'main'", now)
+	merge.ParentHashes = []plumbing.Hash{plumbing.NewHash("1"), plumbing.NewHash("2")}
+	generator.LogIterationStep(merge, bob)
+
+	assert.Equal(t, MessageStats{Commits: 2, SubjectLengthSum: 27, WithBody: 1, Imperative: 1, TrailingPunctuation: 1}, *generator.StatsPerDevMap["Alice"])
+	assert.Equal(t, MessageStats{Commits: 1, SubjectLengthSum: 3, WorkInProgress: 1}, *generator.StatsPerDevMap["Bob"])
+	assert.Equal(t, map[int]int{0: 1, 1: 2}, generator.SubjectLengthsMap)
+}
+
+func TestCommitMessageQualityReportGenerator_GetReports(t *testing.T) {
+	generator := CommitMessageQualityReportGenerator{}
+	now := time.Now()
+
+	generator.LogIterationStep(createMockMessageCommit("Add the parser\n\nDetails", now), Author{Name: "Alice"})
+	generator.LogIterationStep(createMockMessageCommit("Fixed a typo.", now), Author{Name: "Alice"})
+	generator.LogIterationStep(createMockMessageCommit("Update the documentation of every flag of the command line interface, one by one, with examples", now), Author{Name: "Bob"})
+
+	reports := generator.GetReports()
+	assert.Len(t, reports, 2)
+
+	table := reports[0]
+	assert.Equal(t, "Commit message quality", table.GetTitle())
+	assert.Equal(t, "table", table.GetReportType())
+	assert.Equal(t, []string{"All developers", "Alice", "Bob"}, table.GetLabels())
+	assert.Equal(t, []string{"Commits", "Chars per subject", "With a body", "Imperative", "WIP/fixup/squash", "Trailing punctuation"}, columnNames(table))
+	assert.Equal(t, []string{"3", "40", "33.3%", "66.7%", "0", "1"}, formatRow(table, 0))
+	assert.Equal(t, []string{"2", "13", "50%", "50%", "0", "1"}, formatRow(table, 1))
+	assert.Equal(t, table, generator.GetReport())
+
+	histogram := reports[1]
+	assert.Equal(t, "bar_chart", histogram.GetReportType())
+	assert.Len(t, histogram.GetLabels(), 11)
+	assert.Equal(t, "0-9", histogram.GetLabels()[0])
+	assert.Equal(t, "100+", histogram.GetLabels()[10])
+	assert.Equal(t, 2, histogram.GetData()[1].IntValue)
+	assert.Equal(t, 1, histogram.GetData()[9].IntValue)
+}
+
+func columnNames(r report.Report) []string {
+	var names []string
+	for _, column := range r.GetColumns() {
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+// formatRow prints the cells of a row of a table like the printers do.
+func formatRow(r report.Report, row int) []string {
+	var cells []string
+	for i, value := range r.GetRows()[row] {
+		cells = append(cells, value.Format(r.GetColumns()[i].Unit))
+	}
+	return cells
+}
@@ -1,7 +1,10 @@
 package reportgenerator
 
 import (
+	"fmt"
+	"math"
 	"sort"
+	"sync"
 
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/k1-end/git-reports/src/report"
@@ -9,36 +12,77 @@ import (
 
 type CommitsPerDevReportGenerator struct {
     CommitsPerDevMap map[string]int
+    FractionalCommitsPerDevMap map[string]float64 // used instead of CommitsPerDevMap with CoAuthorCreditFractional
+    CoAuthorCredit CoAuthorCredit
+    Authors *Authors // resolves the co-authors through the .mailmap file
+
+    mu sync.Mutex
 }
 
-func (r CommitsPerDevReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
-    _, exists := r.CommitsPerDevMap[a.Name]
-    if !exists {
-        r.CommitsPerDevMap[a.Name] = 1
-    } else {
-        r.CommitsPerDevMap[a.Name]++
+func (r *CommitsPerDevReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    credits := r.CoAuthorCredit.Credits(c, a, r.Authors)
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for _, credit := range credits {
+        if r.CoAuthorCredit == CoAuthorCreditFractional {
+            if r.FractionalCommitsPerDevMap == nil {
+                r.FractionalCommitsPerDevMap = make(map[string]float64)
+            }
+            r.FractionalCommitsPerDevMap[credit.Author.Name] += credit.Share
+            continue
+        }
+        _, exists := r.CommitsPerDevMap[credit.Author.Name]
+        if !exists {
+            r.CommitsPerDevMap[credit.Author.Name] = 1
+        } else {
+            r.CommitsPerDevMap[credit.Author.Name]++
+        }
     }
 }
 
-func (rg CommitsPerDevReportGenerator) GetReport() report.Report {
-    keys := make([]string, 0, len(rg.CommitsPerDevMap))
+func (rg *CommitsPerDevReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    commitsPerDev := rg.CommitsPerDevMap
+    if rg.CoAuthorCredit == CoAuthorCreditFractional {
+        commitsPerDev = make(map[string]int, len(rg.FractionalCommitsPerDevMap))
+        for name, commits := range rg.FractionalCommitsPerDevMap {
+            commitsPerDev[name] = int(math.Round(commits))
+        }
+    }
+    keys := make([]string, 0, len(commitsPerDev))
 
-    for k := range rg.CommitsPerDevMap {
+    for k := range commitsPerDev {
         keys = append(keys, k)
     }
 
     sort.SliceStable(keys, func(i, j int) bool {
-        return rg.CommitsPerDevMap[keys[i]] > rg.CommitsPerDevMap[keys[j]]
+        return commitsPerDev[keys[i]] > commitsPerDev[keys[j]]
     })
     var data []report.Data
+    labels := make([]string, len(keys))
     for k := range keys {
-        data = append(data, report.Data{IsInt: true, IntValue: rg.CommitsPerDevMap[keys[k]], StringValue: ""})
+        data = append(data, report.Data{IsInt: true, IntValue: commitsPerDev[keys[k]], StringValue: ""})
+        labels[k] = keys[k]
+        // The bars can only show whole commits, keep the exact share of the co-authored ones in the label
+        if commits := rg.FractionalCommitsPerDevMap[keys[k]]; rg.CoAuthorCredit == CoAuthorCreditFractional && commits != math.Trunc(commits) {
+            labels[k] = fmt.Sprintf("%s (%.2f)", keys[k], commits)
+        }
     }
 
     r := report.Report{}
-    r.SetLabels(keys)
+    r.SetLabels(labels)
     r.SetData(data)
     r.SetTitle("Commits per developer")
     r.SetReportType("bar_chart")
     return r
 }
+
+// GetReports returns the commits per developer and their share of all the commits.
+func (rg *CommitsPerDevReportGenerator) GetReports() []report.Report {
+    commits := rg.GetReport()
+    share := commits
+    share.SetTitle("Share of commits per developer")
+    share.SetReportType("pie_chart")
+    return []report.Report{commits, share}
+}
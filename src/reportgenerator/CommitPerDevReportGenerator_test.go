@@ -65,6 +65,20 @@ func TestCommitsPerDevReportGenerator_GetReport(t *testing.T) {
 	assert.Equal(t, expectedData, r.GetData(), "Report data should match commit counts in descending order")
 }
 
+func TestCommitsPerDevReportGenerator_GetReports(t *testing.T) {
+	generator := CommitsPerDevReportGenerator{
+		CommitsPerDevMap: map[string]int{"Author A": 10, "Author B": 5},
+	}
+
+	reports := generator.GetReports()
+	assert.Len(t, reports, 2)
+	assert.Equal(t, generator.GetReport(), reports[0])
+	assert.Equal(t, "Share of commits per developer", reports[1].GetTitle())
+	assert.Equal(t, "pie_chart", reports[1].GetReportType())
+	assert.Equal(t, reports[0].GetLabels(), reports[1].GetLabels())
+	assert.Equal(t, reports[0].GetData(), reports[1].GetData())
+}
+
 func TestCommitsPerDevReportGenerator_GetReport_EmptyMap(t *testing.T) {
 	generator := CommitsPerDevReportGenerator{
 		CommitsPerDevMap: make(map[string]int),
@@ -84,3 +98,23 @@ func TestCommitsPerDevReportGenerator_GetReport_EmptyMap(t *testing.T) {
 	// Check data (empty)
 	assert.Empty(t, report.GetData(), "Report data should be empty")
 }
+
+func TestCommitsPerDevReportGenerator_CoAuthorCredit(t *testing.T) {
+	authorA := Author{Name: "Author A", Emails: map[string]bool{"authora@example.com": true}}
+	paired := createMockCoAuthoredCommit("Pair\n\nCo-authored-by: Author B <authorb@example.com>\n")
+	solo := createMockCoAuthoredCommit("Solo")
+
+	full := CommitsPerDevReportGenerator{CommitsPerDevMap: make(map[string]int), CoAuthorCredit: CoAuthorCreditFull}
+	full.LogIterationStep(paired, authorA)
+	full.LogIterationStep(solo, authorA)
+	assert.Equal(t, map[string]int{"Author A": 2, "Author B": 1}, full.CommitsPerDevMap, "Co-authors should get the whole commit")
+
+	fractional := CommitsPerDevReportGenerator{CommitsPerDevMap: make(map[string]int), CoAuthorCredit: CoAuthorCreditFractional}
+	fractional.LogIterationStep(paired, authorA)
+	fractional.LogIterationStep(solo, authorA)
+	assert.Equal(t, map[string]float64{"Author A": 1.5, "Author B": 0.5}, fractional.FractionalCommitsPerDevMap, "Co-authored commits should be split")
+
+	r := fractional.GetReport()
+	assert.Equal(t, []string{"Author A (1.50)", "Author B (0.50)"}, r.GetLabels(), "Labels should keep the exact credit")
+	assert.Equal(t, []report.Data{{IsInt: true, IntValue: 2}, {IsInt: true, IntValue: 1}}, r.GetData(), "Fractional credits should be rounded")
+}
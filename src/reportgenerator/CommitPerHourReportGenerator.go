@@ -2,6 +2,7 @@ package reportgenerator
 
 import (
 	"strconv"
+	"sync"
 
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/k1-end/git-reports/src/report"
@@ -9,13 +10,20 @@ import (
 
 type CommitsPerHourReportGenerator struct {
     CommitsPerHourMap []int
+    Identity Identity // signature giving the date of the commits
+
+    mu sync.Mutex
 }
 
-func (r CommitsPerHourReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
-	r.CommitsPerHourMap[c.Author.When.Local().Hour()]++
+func (r *CommitsPerHourReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+	r.CommitsPerHourMap[r.Identity.Signature(c).When.Local().Hour()]++
 }
 
-func (rg CommitsPerHourReportGenerator) GetReport() report.Report {
+func (rg *CommitsPerHourReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
     var data []report.Data
     var labels []string
     for i := 1; i < 24; i++ {
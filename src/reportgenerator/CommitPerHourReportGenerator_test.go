@@ -132,3 +132,17 @@ func TestCommitsPerHourReportGenerator_GetReport_EmptyMap(t *testing.T) {
 	assert.Equal(t, expectedData, r.GetData(), "Report data should be all zeros")
 }
 
+
+func TestCommitsPerHourReportGenerator_LogIterationStep_Committer(t *testing.T) {
+	generator := CommitsPerHourReportGenerator{
+		CommitsPerHourMap: make([]int, 24),
+		Identity:          IdentityCommitter,
+	}
+
+	// A patch written at 09:00 and applied by a maintainer at 17:00
+	commit := createMockCommit("Author A", "authora@example.com", time.Date(2024, time.January, 15, 9, 0, 0, 0, time.Local))
+	commit.Committer.When = time.Date(2024, time.January, 15, 17, 0, 0, 0, time.Local)
+	generator.LogIterationStep(commit, Author{Name: "Author B"})
+	assert.Equal(t, 0, generator.CommitsPerHourMap[9], "The author time should be ignored")
+	assert.Equal(t, 1, generator.CommitsPerHourMap[17], "The committer time should be used")
+}
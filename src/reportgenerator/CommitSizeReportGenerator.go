@@ -0,0 +1,172 @@
+package reportgenerator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+)
+
+// maxCommitSizeAuthors limits the rows of the per developer table.
+const maxCommitSizeAuthors = 20
+
+// CommitSize is a size class of commits, from the smallest to the biggest.
+type CommitSize struct {
+    Name string
+    MaxLines int // lines added and deleted
+    MaxFiles int
+}
+
+// CommitSizes are the size classes. A commit belongs to the first class fitting both its
+// lines and files changed, the last class has no limit.
+var CommitSizes = []CommitSize{
+    {Name: "tiny", MaxLines: 10, MaxFiles: 1},
+    {Name: "small", MaxLines: 100, MaxFiles: 5},
+    {Name: "medium", MaxLines: 400, MaxFiles: 15},
+    {Name: "large", MaxLines: 1000, MaxFiles: 50},
+    {Name: "huge"},
+}
+
+// ClassifyCommitSize returns the index in CommitSizes of the class of a commit.
+func ClassifyCommitSize(lines int, files int) int {
+    for i, size := range CommitSizes[:len(CommitSizes)-1] {
+        if lines <= size.MaxLines && files <= size.MaxFiles {
+            return i
+        }
+    }
+    return len(CommitSizes) - 1
+}
+
+// CommitSizeReportGenerator reports the distribution of the commit sizes, overall and per developer.
+// Merge commits are ignored, their diff against the first parent is the whole merged branch.
+// The co-authors are not credited, splitting a commit would make it look smaller than it is.
+type CommitSizeReportGenerator struct {
+    SizesMap map[int]int // index in CommitSizes => commits
+    SizesPerDevMap map[string]map[int]int
+    LinesPerDevMap map[string][]int // lines changed by every commit of the developer
+
+    mu sync.Mutex
+}
+
+func (r *CommitSizeReportGenerator) StatsIterationStep(c *object.Commit, a Author, stats object.FileStats)  {
+    if c.NumParents() > 1 {
+        return
+    }
+    lines := 0
+    for _, file := range stats {
+        lines += file.Addition + file.Deletion
+    }
+    size := ClassifyCommitSize(lines, len(stats))
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.SizesMap == nil {
+        r.SizesMap = make(map[int]int)
+        r.SizesPerDevMap = make(map[string]map[int]int)
+        r.LinesPerDevMap = make(map[string][]int)
+    }
+    if r.SizesPerDevMap[a.Name] == nil {
+        r.SizesPerDevMap[a.Name] = make(map[int]int)
+    }
+    r.SizesMap[size]++
+    r.SizesPerDevMap[a.Name][size]++
+    r.LinesPerDevMap[a.Name] = append(r.LinesPerDevMap[a.Name], lines)
+}
+
+// GetReport returns the distribution of the commit sizes, see GetReports for the developers.
+func (rg *CommitSizeReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return rg.distributionReport()
+}
+
+// GetReports returns the distribution of the commit sizes overall and per developer.
+func (rg *CommitSizeReportGenerator) GetReports() []report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return []report.Report{rg.distributionReport(), rg.developersReport()}
+}
+
+func (rg *CommitSizeReportGenerator) distributionReport() report.Report {
+    var labels []string
+    var data []report.Data
+    for i := range CommitSizes {
+        labels = append(labels, commitSizeLabel(i))
+        data = append(data, report.Data{IsInt: true, IntValue: rg.SizesMap[i]})
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetData(data)
+    r.SetTitle("Commit size distribution")
+    r.SetReportType("bar_chart")
+    return r
+}
+
+// commitSizeLabel describes a size class, e.g. "small (up to 100 lines and 5 files)".
+func commitSizeLabel(i int) string {
+    if i == len(CommitSizes)-1 {
+        previous := CommitSizes[i-1]
+        return fmt.Sprintf("%s (over %d lines or %d files)", CommitSizes[i].Name, previous.MaxLines, previous.MaxFiles)
+    }
+    size := CommitSizes[i]
+    if size.MaxFiles == 1 {
+        return fmt.Sprintf("%s (up to %d lines in 1 file)", size.Name, size.MaxLines)
+    }
+    return fmt.Sprintf("%s (up to %d lines and %d files)", size.Name, size.MaxLines, size.MaxFiles)
+}
+
+func (rg *CommitSizeReportGenerator) developersReport() report.Report {
+    names := make([]string, 0, len(rg.LinesPerDevMap))
+    var allLines []int
+    for name, lines := range rg.LinesPerDevMap {
+        names = append(names, name)
+        allLines = append(allLines, lines...)
+    }
+    sort.Slice(names, func(i, j int) bool {
+        if len(rg.LinesPerDevMap[names[i]]) != len(rg.LinesPerDevMap[names[j]]) {
+            return len(rg.LinesPerDevMap[names[i]]) > len(rg.LinesPerDevMap[names[j]])
+        }
+        return names[i] < names[j]
+    })
+    names = names[:min(len(names), maxCommitSizeAuthors)]
+
+    labels := []string{"All developers"}
+    rows := [][]report.Value{commitSizesRow(rg.SizesMap, allLines)}
+    for _, name := range names {
+        labels = append(labels, name)
+        rows = append(rows, commitSizesRow(rg.SizesPerDevMap[name], rg.LinesPerDevMap[name]))
+    }
+
+    columns := make([]report.Column, 0, len(CommitSizes)+1)
+    for _, size := range CommitSizes {
+        columns = append(columns, report.Column{Name: strings.ToUpper(size.Name[:1]) + size.Name[1:], Type: report.TypeInt})
+    }
+    columns = append(columns, report.Column{Name: "Median lines", Type: report.TypeInt})
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetColumns(columns)
+    r.SetRows(rows)
+    r.SetTitle("Commit size per developer")
+    r.SetReportType("table")
+    return r
+}
+
+// commitSizesRow returns the commits per size class and the median lines changed.
+func commitSizesRow(sizes map[int]int, lines []int) []report.Value {
+    row := make([]report.Value, 0, len(CommitSizes)+1)
+    for i := range CommitSizes {
+        row = append(row, report.IntValue(sizes[i]))
+    }
+    median := 0
+    if len(lines) > 0 {
+        sorted := append([]int(nil), lines...)
+        sort.Ints(sorted)
+        median = sorted[len(sorted)/2]
+    }
+    return append(row, report.IntValue(median))
+}
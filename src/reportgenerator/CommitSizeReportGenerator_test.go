@@ -0,0 +1,76 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyCommitSize(t *testing.T) {
+	testCases := []struct {
+		lines       int
+		files       int
+		expected    string
+		description string
+	}{
+		{0, 0, "tiny", "Empty commit"},
+		{10, 1, "tiny", "Upper bound of tiny"},
+		{5, 2, "small", "Two files are not tiny"},
+		{100, 5, "small", "Upper bound of small"},
+		{101, 1, "medium", "Lines alone make it bigger"},
+		{20, 16, "large", "Files alone make it bigger"},
+		{1000, 50, "large", "Upper bound of large"},
+		{5000, 3, "huge", "Huge"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, CommitSizes[ClassifyCommitSize(tc.lines, tc.files)].Name)
+		})
+	}
+}
+
+func TestCommitSizeReportGenerator_GetReports(t *testing.T) {
+	generator := CommitSizeReportGenerator{}
+	commit := createMockCommit("Author A", "authora@example.com", time.Now())
+	alice := Author{Name: "Alice"}
+	bob := Author{Name: "Bob"}
+
+	generator.StatsIterationStep(commit, alice, object.FileStats{{Name: "a.go", Addition: 3, Deletion: 1}})
+	generator.StatsIterationStep(commit, alice, object.FileStats{{Name: "a.go", Addition: 30}, {Name: "b.go", Deletion: 20}})
+	generator.StatsIterationStep(commit, alice, object.FileStats{{Name: "a.go", Addition: 2}})
+	generator.StatsIterationStep(commit, bob, object.FileStats{{Name: "big.sql", Addition: 4000}})
+	merge := createMockCommit("Author A", "authora@example.com", time.Now())
+	merge.ParentHashes = []plumbing.Hash{plumbing.NewHash("1"), plumbing.NewHash("2")}
+	generator.StatsIterationStep(merge, bob, object.FileStats{{Name: "big.sql", Addition: 4000}})
+
+	assert.Equal(t, map[int]int{0: 2, 1: 1, 4: 1}, generator.SizesMap)
+	assert.Equal(t, []int{4, 50, 2}, generator.LinesPerDevMap["Alice"])
+
+	reports := generator.GetReports()
+	require.Len(t, reports, 2)
+
+	distribution := reports[0]
+	assert.Equal(t, "Commit size distribution", distribution.GetTitle())
+	assert.Equal(t, "bar_chart", distribution.GetReportType())
+	assert.Equal(t, "tiny (up to 10 lines in 1 file)", distribution.GetLabels()[0])
+	assert.Equal(t, "small (up to 100 lines and 5 files)", distribution.GetLabels()[1])
+	assert.Equal(t, "huge (over 1000 lines or 50 files)", distribution.GetLabels()[4])
+	assert.Equal(t, []int{2, 1, 0, 0, 1}, []int{
+		distribution.GetData()[0].IntValue, distribution.GetData()[1].IntValue, distribution.GetData()[2].IntValue,
+		distribution.GetData()[3].IntValue, distribution.GetData()[4].IntValue,
+	})
+	assert.Equal(t, distribution, generator.GetReport())
+
+	developers := reports[1]
+	assert.Equal(t, "table", developers.GetReportType())
+	assert.Equal(t, []string{"All developers", "Alice", "Bob"}, developers.GetLabels())
+	assert.Equal(t, []string{"Tiny", "Small", "Medium", "Large", "Huge", "Median lines"}, columnNames(developers))
+	assert.Equal(t, []string{"2", "1", "0", "0", "1", "50"}, formatRow(developers, 0))
+	assert.Equal(t, []string{"2", "1", "0", "0", "0", "4"}, formatRow(developers, 1))
+	assert.Equal(t, []string{"0", "0", "0", "0", "1", "4000"}, formatRow(developers, 2))
+}
@@ -0,0 +1,229 @@
+package reportgenerator
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// maxLifecycleAuthors limits the rows of the contributor table to the most active developers.
+const maxLifecycleAuthors = 20
+
+// DefaultInactivityPeriod is how long a developer has not committed before being listed as inactive.
+const DefaultInactivityPeriod = 90 * 24 * time.Hour
+
+// Contributor is the activity of a developer over the analyzed history.
+type Contributor struct {
+    FirstCommit time.Time
+    LastCommit time.Time
+    ActiveDays map[string]bool // "2006-01-02" in the local time zone
+}
+
+// Tenure returns the number of days from the first to the last commit, both included.
+func (c *Contributor) Tenure() int {
+    first, _ := time.Parse(time.DateOnly, c.FirstCommit.Format(time.DateOnly))
+    last, _ := time.Parse(time.DateOnly, c.LastCommit.Format(time.DateOnly))
+    return int(last.Sub(first).Hours()/24) + 1
+}
+
+// LongestStreak returns the highest number of consecutive days with commits.
+func (c *Contributor) LongestStreak() int {
+    days := make([]time.Time, 0, len(c.ActiveDays))
+    for day := range c.ActiveDays {
+        parsed, _ := time.Parse(time.DateOnly, day)
+        days = append(days, parsed)
+    }
+    sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+    longest, streak := 0, 0
+    for i, day := range days {
+        if i > 0 && days[i-1].AddDate(0, 0, 1).Equal(day) {
+            streak++
+        } else {
+            streak = 1
+        }
+        longest = max(longest, streak)
+    }
+    return longest
+}
+
+// ContributorLifecycleReportGenerator follows when developers join and stop contributing.
+// A developer is inactive when their last commit is older than InactivityPeriod at the date
+// of the last analyzed commit, so that old histories give meaningful results too.
+type ContributorLifecycleReportGenerator struct {
+    Identity Identity // signature giving the date of the commits
+    InactivityPeriod time.Duration // DefaultInactivityPeriod when zero
+    CoAuthorCredit CoAuthorCredit // co-authors contribute too unless CoAuthorCreditAuthorOnly
+    Authors *Authors // resolves the co-authors through the .mailmap file
+
+    ContributorsMap map[string]*Contributor
+    LastCommit time.Time
+
+    mu sync.Mutex
+}
+
+func (r *ContributorLifecycleReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    when := r.Identity.Signature(c).When.Local()
+    day := when.Format(time.DateOnly)
+    credits := r.CoAuthorCredit.Credits(c, a, r.Authors)
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.ContributorsMap == nil {
+        r.ContributorsMap = make(map[string]*Contributor)
+    }
+    for _, credit := range credits {
+        contributor, exists := r.ContributorsMap[credit.Author.Name]
+        if !exists {
+            contributor = &Contributor{FirstCommit: when, LastCommit: when, ActiveDays: make(map[string]bool)}
+            r.ContributorsMap[credit.Author.Name] = contributor
+        }
+        if when.Before(contributor.FirstCommit) {
+            contributor.FirstCommit = when
+        }
+        if when.After(contributor.LastCommit) {
+            contributor.LastCommit = when
+        }
+        contributor.ActiveDays[day] = true
+    }
+    if when.After(r.LastCommit) {
+        r.LastCommit = when
+    }
+}
+
+// GetReport returns the activity of the most active developers, see GetReports for the arrivals and departures.
+func (rg *ContributorLifecycleReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return rg.contributorsReport()
+}
+
+// GetReports returns the activity of the most active developers, the new contributors per
+// month and the inactive contributors.
+func (rg *ContributorLifecycleReportGenerator) GetReports() []report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return []report.Report{rg.contributorsReport(), rg.newContributorsReport(), rg.inactiveContributorsReport()}
+}
+
+func (rg *ContributorLifecycleReportGenerator) contributorsReport() report.Report {
+    names := make([]string, 0, len(rg.ContributorsMap))
+    for name := range rg.ContributorsMap {
+        names = append(names, name)
+    }
+    sort.Slice(names, func(i, j int) bool {
+        if len(rg.ContributorsMap[names[i]].ActiveDays) != len(rg.ContributorsMap[names[j]].ActiveDays) {
+            return len(rg.ContributorsMap[names[i]].ActiveDays) > len(rg.ContributorsMap[names[j]].ActiveDays)
+        }
+        return names[i] < names[j]
+    })
+    names = names[:min(len(names), maxLifecycleAuthors)]
+
+    var rows [][]report.Value
+    for _, name := range names {
+        contributor := rg.ContributorsMap[name]
+        rows = append(rows, []report.Value{
+            report.DateValue(contributor.FirstCommit),
+            report.DateValue(contributor.LastCommit),
+            report.IntValue(contributor.Tenure()),
+            report.IntValue(len(contributor.ActiveDays)),
+            report.IntValue(contributor.LongestStreak()),
+        })
+    }
+
+    r := report.Report{}
+    r.SetLabels(names)
+    r.SetColumns([]report.Column{
+        {Name: "First commit", Type: report.TypeDate},
+        {Name: "Last commit", Type: report.TypeDate},
+        {Name: "Tenure (days)", Type: report.TypeInt},
+        {Name: "Active days", Type: report.TypeInt},
+        {Name: "Longest streak (days)", Type: report.TypeInt},
+    })
+    r.SetRows(rows)
+    r.SetTitle("Contributor lifecycle")
+    r.SetReportType("table")
+    return r
+}
+
+func (rg *ContributorLifecycleReportGenerator) newContributorsReport() report.Report {
+    joined := make(map[string]int)
+    var first, last time.Time
+    for _, contributor := range rg.ContributorsMap {
+        joined[contributor.FirstCommit.Format("2006-01")]++
+        if first.IsZero() || contributor.FirstCommit.Before(first) {
+            first = contributor.FirstCommit
+        }
+        if contributor.FirstCommit.After(last) {
+            last = contributor.FirstCommit
+        }
+    }
+
+    // Every month is listed, the ones without newcomers too
+    var labels []string
+    var data []report.Data
+    if !first.IsZero() {
+        month := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.Local)
+        for !month.After(last) {
+            label := month.Format("2006-01")
+            labels = append(labels, label)
+            data = append(data, report.Data{IsInt: true, IntValue: joined[label]})
+            month = month.AddDate(0, 1, 0)
+        }
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetData(data)
+    r.SetTitle("New contributors per month")
+    r.SetReportType("bar_chart")
+    return r
+}
+
+func (rg *ContributorLifecycleReportGenerator) inactiveContributorsReport() report.Report {
+    period := rg.InactivityPeriod
+    if period == 0 {
+        period = DefaultInactivityPeriod
+    }
+
+    var names []string
+    for name, contributor := range rg.ContributorsMap {
+        if rg.LastCommit.Sub(contributor.LastCommit) > period {
+            names = append(names, name)
+        }
+    }
+    // The developers who left recently first
+    sort.Slice(names, func(i, j int) bool {
+        if !rg.ContributorsMap[names[i]].LastCommit.Equal(rg.ContributorsMap[names[j]].LastCommit) {
+            return rg.ContributorsMap[names[i]].LastCommit.After(rg.ContributorsMap[names[j]].LastCommit)
+        }
+        return names[i] < names[j]
+    })
+
+    p := message.NewPrinter(language.English)
+    var rows [][]report.Value
+    for _, name := range names {
+        contributor := rg.ContributorsMap[name]
+        rows = append(rows, []report.Value{
+            report.DateValue(contributor.LastCommit),
+            report.IntValue(int(rg.LastCommit.Sub(contributor.LastCommit).Hours() / 24)),
+        })
+    }
+
+    r := report.Report{}
+    r.SetLabels(names)
+    r.SetColumns([]report.Column{
+        {Name: "Last commit", Type: report.TypeDate},
+        {Name: "Inactive for (days)", Type: report.TypeInt},
+    })
+    r.SetRows(rows)
+    r.SetNote("Inactivity is measured up to the last commit of the history")
+    r.SetTitle(p.Sprintf("Contributors inactive for more than %d days", int(period.Hours()/24)))
+    r.SetReportType("table")
+    return r
+}
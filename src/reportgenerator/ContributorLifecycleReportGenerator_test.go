@@ -0,0 +1,92 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContributor_LongestStreak(t *testing.T) {
+	testCases := []struct {
+		days        []string
+		expected    int
+		description string
+	}{
+		{nil, 0, "No activity"},
+		{[]string{"2024-01-01"}, 1, "Single day"},
+		{[]string{"2024-01-01", "2024-01-02", "2024-01-04"}, 2, "Gap"},
+		{[]string{"2024-02-28", "2024-02-29", "2024-03-01", "2024-01-05"}, 3, "Across a month"},
+		{[]string{"2023-12-31", "2024-01-01"}, 2, "Across a year"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			contributor := Contributor{ActiveDays: make(map[string]bool)}
+			for _, day := range tc.days {
+				contributor.ActiveDays[day] = true
+			}
+			assert.Equal(t, tc.expected, contributor.LongestStreak())
+		})
+	}
+}
+
+func TestContributorLifecycleReportGenerator_GetReports(t *testing.T) {
+	generator := ContributorLifecycleReportGenerator{}
+	alice := Author{Name: "Alice"}
+	bob := Author{Name: "Bob"}
+	day := func(month time.Month, date int) time.Time {
+		return time.Date(2024, month, date, 12, 0, 0, 0, time.Local)
+	}
+
+	generator.LogIterationStep(createMockCommit("Alice", "alice@example.com", day(time.January, 2)), alice)
+	generator.LogIterationStep(createMockCommit("Alice", "alice@example.com", day(time.January, 1)), alice)
+	generator.LogIterationStep(createMockCommit("Alice", "alice@example.com", day(time.January, 1)), alice)
+	generator.LogIterationStep(createMockCommit("Alice", "alice@example.com", day(time.January, 10)), alice)
+	generator.LogIterationStep(createMockCommit("Bob", "bob@example.com", day(time.March, 5)), bob)
+	generator.LogIterationStep(createMockCommit("Bob", "bob@example.com", day(time.June, 20)), bob)
+
+	assert.Equal(t, day(time.January, 1), generator.ContributorsMap["Alice"].FirstCommit)
+	assert.Equal(t, day(time.January, 10), generator.ContributorsMap["Alice"].LastCommit)
+	assert.Equal(t, 10, generator.ContributorsMap["Alice"].Tenure())
+	assert.Equal(t, day(time.June, 20), generator.LastCommit)
+
+	reports := generator.GetReports()
+	require.Len(t, reports, 3)
+
+	contributors := reports[0]
+	assert.Equal(t, "Contributor lifecycle", contributors.GetTitle())
+	assert.Equal(t, "table", contributors.GetReportType())
+	assert.Equal(t, []string{"Alice", "Bob"}, contributors.GetLabels())
+	assert.Equal(t, []string{"First commit", "Last commit", "Tenure (days)", "Active days", "Longest streak (days)"}, columnNames(contributors))
+	assert.Equal(t, []string{"2024-01-01", "2024-01-10", "10", "3", "2"}, formatRow(contributors, 0))
+	assert.Equal(t, contributors, generator.GetReport())
+
+	newContributors := reports[1]
+	assert.Equal(t, "bar_chart", newContributors.GetReportType())
+	assert.Equal(t, []string{"2024-01", "2024-02", "2024-03"}, newContributors.GetLabels())
+	assert.Equal(t, []int{1, 0, 1}, []int{newContributors.GetData()[0].IntValue, newContributors.GetData()[1].IntValue, newContributors.GetData()[2].IntValue})
+
+	inactive := reports[2]
+	assert.Equal(t, "Contributors inactive for more than 90 days", inactive.GetTitle())
+	assert.Equal(t, []string{"Alice"}, inactive.GetLabels())
+	assert.Equal(t, []string{"2024-01-10", "162"}, formatRow(inactive, 0))
+
+	generator.InactivityPeriod = 200 * 24 * time.Hour
+	assert.Empty(t, generator.GetReports()[2].GetLabels())
+}
+
+func TestContributorLifecycleReportGenerator_CoAuthorCredit(t *testing.T) {
+	authorA := Author{Name: "Author A", Emails: map[string]bool{"authora@example.com": true}}
+	paired := createMockCoAuthoredCommit("Pair\n\nCo-authored-by: Author B <authorb@example.com>\n")
+
+	generator := ContributorLifecycleReportGenerator{CoAuthorCredit: CoAuthorCreditFractional}
+	generator.LogIterationStep(paired, authorA)
+	assert.Equal(t, []string{"Author A", "Author B"}, generator.GetReport().GetLabels(), "Co-authors should be contributors whatever their share")
+	assert.Equal(t, 1, len(generator.ContributorsMap["Author B"].ActiveDays))
+
+	authorOnly := ContributorLifecycleReportGenerator{}
+	authorOnly.LogIterationStep(paired, authorA)
+	assert.Equal(t, []string{"Author A"}, authorOnly.GetReport().GetLabels())
+}
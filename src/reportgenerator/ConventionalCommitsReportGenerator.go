@@ -0,0 +1,213 @@
+package reportgenerator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// maxConventionalCommitScopes limits the scopes shown, big projects have a long tail of them.
+const maxConventionalCommitScopes = 20
+
+// ConventionalCommit is the parsed subject of a commit following https://www.conventionalcommits.org.
+type ConventionalCommit struct {
+    Type     string
+    Scope    string
+    Breaking bool
+}
+
+// DefaultConventionalTypes are the types of the Angular convention that Conventional Commits is based on.
+var DefaultConventionalTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+var conventionalSubject = regexp.MustCompile(`^([A-Za-z][\w-]*)(?:\(([^()\r\n]*)\))?(!)?: \S`)
+var breakingFooter = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+
+// ParseConventionalCommit parses the subject of a commit message. It returns false when
+// the message does not follow the convention or its type is not one of the given types,
+// so that prefixes like "cmd: " are not taken for a type.
+func ParseConventionalCommit(msg string, types []string) (ConventionalCommit, bool) {
+    subject, body, _ := strings.Cut(msg, "\n")
+    match := conventionalSubject.FindStringSubmatch(subject)
+    if match == nil || !slices.Contains(types, strings.ToLower(match[1])) {
+        return ConventionalCommit{}, false
+    }
+    return ConventionalCommit{
+        Type:     strings.ToLower(match[1]),
+        Scope:    strings.TrimSpace(match[2]),
+        Breaking: match[3] == "!" || breakingFooter.MatchString(body),
+    }, true
+}
+
+// ConventionalCommitsReportGenerator counts the commits per Conventional Commits type and scope.
+// Merge commits are ignored, their subject is written by git.
+type ConventionalCommitsReportGenerator struct {
+    Identity Identity // signature giving the date of the commits
+    Types []string // accepted types, in lower case, DefaultConventionalTypes when empty
+
+    TypesMap map[string]int
+    ScopesMap map[string]int
+    TypesPerQuarterMap map[string]map[string]int // "2024 Q1" => type => commits
+    ConformingNo int
+    NonConformingNo int
+    NonConformingPerQuarterMap map[string]int
+    BreakingNo int
+
+    mu sync.Mutex
+}
+
+func (r *ConventionalCommitsReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    if c.NumParents() > 1 {
+        return
+    }
+    types := r.Types
+    if len(types) == 0 {
+        types = DefaultConventionalTypes
+    }
+    parsed, conforming := ParseConventionalCommit(c.Message, types)
+    when := r.Identity.Signature(c).When.Local()
+    quarter := fmt.Sprintf("%d Q%d", when.Year(), (int(when.Month())-1)/3+1)
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.TypesMap == nil {
+        r.TypesMap = make(map[string]int)
+        r.ScopesMap = make(map[string]int)
+        r.TypesPerQuarterMap = make(map[string]map[string]int)
+        r.NonConformingPerQuarterMap = make(map[string]int)
+    }
+    if !conforming {
+        r.NonConformingNo++
+        r.NonConformingPerQuarterMap[quarter]++
+        return
+    }
+    r.ConformingNo++
+    r.TypesMap[parsed.Type]++
+    if parsed.Scope != "" {
+        r.ScopesMap[parsed.Scope]++
+    }
+    if parsed.Breaking {
+        r.BreakingNo++
+    }
+    if r.TypesPerQuarterMap[quarter] == nil {
+        r.TypesPerQuarterMap[quarter] = make(map[string]int)
+    }
+    r.TypesPerQuarterMap[quarter][parsed.Type]++
+}
+
+// GetReport returns the adherence to the convention, see GetReports for the breakdowns.
+func (rg *ConventionalCommitsReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return rg.summaryReport()
+}
+
+// GetReports returns the adherence and the commits per type, per scope and per quarter.
+func (rg *ConventionalCommitsReportGenerator) GetReports() []report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return []report.Report{
+        rg.summaryReport(),
+        countsReport("Commits per Conventional Commits type", rg.TypesMap, 0),
+        countsReport("Commits per Conventional Commits scope", rg.ScopesMap, maxConventionalCommitScopes),
+        rg.quarterReport(),
+    }
+}
+
+func (rg *ConventionalCommitsReportGenerator) summaryReport() report.Report {
+    p := message.NewPrinter(language.English)
+    total := rg.ConformingNo + rg.NonConformingNo
+    labels := []string{"Conforming commits", "Non-conforming commits", "Breaking changes", "Features per fix"}
+    data := []report.Data{
+        {IsInt: false, StringValue: p.Sprintf("%d (%d%%)", rg.ConformingNo, percentage(rg.ConformingNo, total))},
+        {IsInt: false, StringValue: p.Sprintf("%d (%d%%)", rg.NonConformingNo, percentage(rg.NonConformingNo, total))},
+        {IsInt: false, StringValue: p.Sprintf("%d", rg.BreakingNo)},
+        {IsInt: false, StringValue: featureFixRatio(rg.TypesMap)},
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetData(data)
+    r.SetTitle("Conventional Commits")
+    r.SetReportType("table")
+    return r
+}
+
+func (rg *ConventionalCommitsReportGenerator) quarterReport() report.Report {
+    quarters := make([]string, 0, len(rg.TypesPerQuarterMap))
+    for quarter := range rg.TypesPerQuarterMap {
+        quarters = append(quarters, quarter)
+    }
+    for quarter := range rg.NonConformingPerQuarterMap {
+        if _, exists := rg.TypesPerQuarterMap[quarter]; !exists {
+            quarters = append(quarters, quarter)
+        }
+    }
+    sort.Strings(quarters) // "2024 Q1" sorts chronologically
+
+    p := message.NewPrinter(language.English)
+    var data []report.Data
+    for _, quarter := range quarters {
+        types := rg.TypesPerQuarterMap[quarter]
+        others := 0
+        for t, commits := range types {
+            if t != "feat" && t != "fix" {
+                others += commits
+            }
+        }
+        data = append(data, report.Data{IsInt: false, StringValue: p.Sprintf(
+            "%d feat, %d fix, %d other, %d non-conforming (features per fix: %s)",
+            types["feat"], types["fix"], others, rg.NonConformingPerQuarterMap[quarter], featureFixRatio(types),
+        )})
+    }
+
+    r := report.Report{}
+    r.SetLabels(quarters)
+    r.SetData(data)
+    r.SetTitle("Conventional Commits per quarter")
+    r.SetReportType("table")
+    return r
+}
+
+// featureFixRatio returns the number of feat commits per fix commit.
+func featureFixRatio(types map[string]int) string {
+    if types["fix"] == 0 {
+        return "-"
+    }
+    return fmt.Sprintf("%.2f", float64(types["feat"])/float64(types["fix"]))
+}
+
+// countsReport returns a bar chart of the counts, the biggest first, limited to limit bars unless it is 0.
+func countsReport(title string, counts map[string]int, limit int) report.Report {
+    keys := make([]string, 0, len(counts))
+    for k := range counts {
+        keys = append(keys, k)
+    }
+    sort.Slice(keys, func(i, j int) bool {
+        if counts[keys[i]] != counts[keys[j]] {
+            return counts[keys[i]] > counts[keys[j]]
+        }
+        return keys[i] < keys[j]
+    })
+    if limit > 0 && len(keys) > limit {
+        keys = keys[:limit]
+    }
+
+    var data []report.Data
+    for _, k := range keys {
+        data = append(data, report.Data{IsInt: true, IntValue: counts[k]})
+    }
+    r := report.Report{}
+    r.SetLabels(keys)
+    r.SetData(data)
+    r.SetTitle(title)
+    r.SetReportType("bar_chart")
+    return r
+}
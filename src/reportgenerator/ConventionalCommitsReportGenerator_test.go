@@ -0,0 +1,120 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockMessageCommit(message string, commitTime time.Time) *object.Commit {
+	commit := createMockCommit("Author A", "authora@example.com", commitTime)
+	commit.Message = message
+	return commit
+}
+
+func TestParseConventionalCommit(t *testing.T) {
+	testCases := []struct {
+		message     string
+		expected    ConventionalCommit
+		conforming  bool
+		description string
+	}{
+		{"feat: add the parser", ConventionalCommit{Type: "feat"}, true, "Type only"},
+		{"fix(cli): handle empty paths\n\nDetails", ConventionalCommit{Type: "fix", Scope: "cli"}, true, "Type and scope"},
+		{"Feat(API)!: drop v1", ConventionalCommit{Type: "feat", Scope: "API", Breaking: true}, true, "Breaking marker"},
+		{"refactor: rename\n\nBREAKING CHANGE: the config key changed", ConventionalCommit{Type: "refactor", Breaking: true}, true, "Breaking footer"},
+		{"chore(deps-dev): bump go-git", ConventionalCommit{Type: "chore", Scope: "deps-dev"}, true, "Scope with a dash"},
+		{"Add the parser", ConventionalCommit{}, false, "No type"},
+		{"feat:add the parser", ConventionalCommit{}, false, "Missing space"},
+		{"feat: ", ConventionalCommit{}, false, "Missing description"},
+		{"Merge branch 'main' into feature", ConventionalCommit{}, false, "Merge subject"},
+		{"fix the build\n\nfeat: not in the subject", ConventionalCommit{}, false, "Only the subject counts"},
+		{"cmd: fix the flags", ConventionalCommit{}, false, "Go style package prefix"},
+		{"README: fix a typo", ConventionalCommit{}, false, "File name prefix"},
+		{"WIP: try again", ConventionalCommit{}, false, "Unknown type"},
+		{"feature(cli): add flags", ConventionalCommit{}, false, "Misspelled type"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			parsed, conforming := ParseConventionalCommit(tc.message, DefaultConventionalTypes)
+			assert.Equal(t, tc.conforming, conforming, "Conformance of %q", tc.message)
+			assert.Equal(t, tc.expected, parsed, "Parsed subject of %q", tc.message)
+		})
+	}
+}
+
+func TestConventionalCommitsReportGenerator_LogIterationStep(t *testing.T) {
+	generator := ConventionalCommitsReportGenerator{}
+	january := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.Local)
+	may := time.Date(2024, time.May, 15, 10, 0, 0, 0, time.Local)
+
+	generator.LogIterationStep(createMockMessageCommit("feat(cli): add flags", january), Author{})
+	generator.LogIterationStep(createMockMessageCommit("fix(cli): typo", january), Author{})
+	generator.LogIterationStep(createMockMessageCommit("feat!: new format", may), Author{})
+	generator.LogIterationStep(createMockMessageCommit("Update README", may), Author{})
+	merge := createMockMessageCommit("Merge pull request #1", may)
+	merge.ParentHashes = []plumbing.Hash{plumbing.NewHash("1"), plumbing.NewHash("2")}
+	generator.LogIterationStep(merge, Author{})
+
+	assert.Equal(t, 3, generator.ConformingNo)
+	assert.Equal(t, 1, generator.NonConformingNo, "Merge commits should be ignored")
+	assert.Equal(t, 1, generator.BreakingNo)
+	assert.Equal(t, map[string]int{"feat": 2, "fix": 1}, generator.TypesMap)
+	assert.Equal(t, map[string]int{"cli": 2}, generator.ScopesMap)
+	assert.Equal(t, map[string]map[string]int{"2024 Q1": {"feat": 1, "fix": 1}, "2024 Q2": {"feat": 1}}, generator.TypesPerQuarterMap)
+	assert.Equal(t, map[string]int{"2024 Q2": 1}, generator.NonConformingPerQuarterMap)
+}
+
+func TestConventionalCommitsReportGenerator_Types(t *testing.T) {
+	generator := ConventionalCommitsReportGenerator{Types: []string{"feat", "fix", "deps"}}
+	now := time.Now()
+
+	generator.LogIterationStep(createMockMessageCommit("deps: bump go-git", now), Author{})
+	generator.LogIterationStep(createMockMessageCommit("chore: tidy", now), Author{})
+
+	assert.Equal(t, 1, generator.ConformingNo, "Only the configured types should conform")
+	assert.Equal(t, map[string]int{"deps": 1}, generator.TypesMap)
+}
+
+func TestConventionalCommitsReportGenerator_GetReports(t *testing.T) {
+	generator := ConventionalCommitsReportGenerator{}
+	january := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.Local)
+	october := time.Date(2023, time.October, 15, 10, 0, 0, 0, time.Local)
+	for _, message := range []string{"feat: a", "feat: b", "feat(ui): c", "fix: d", "docs(ui): e", "wip"} {
+		generator.LogIterationStep(createMockMessageCommit(message, january), Author{})
+	}
+	generator.LogIterationStep(createMockMessageCommit("Initial commit", october), Author{})
+
+	reports := generator.GetReports()
+	assert.Len(t, reports, 4)
+
+	summary := reports[0]
+	assert.Equal(t, generator.GetReport(), summary, "GetReport should return the summary")
+	assert.Equal(t, "Conventional Commits", summary.GetTitle())
+	assert.Equal(t, []report.Data{
+		{IsInt: false, StringValue: "5 (71%)"},
+		{IsInt: false, StringValue: "2 (29%)"},
+		{IsInt: false, StringValue: "0"},
+		{IsInt: false, StringValue: "3.00"},
+	}, summary.GetData())
+
+	types := reports[1]
+	assert.Equal(t, "bar_chart", types.GetReportType())
+	assert.Equal(t, []string{"feat", "docs", "fix"}, types.GetLabels(), "Types should be sorted by commits, then by name")
+	assert.Equal(t, []report.Data{{IsInt: true, IntValue: 3}, {IsInt: true, IntValue: 1}, {IsInt: true, IntValue: 1}}, types.GetData())
+
+	scopes := reports[2]
+	assert.Equal(t, []string{"ui"}, scopes.GetLabels())
+
+	quarters := reports[3]
+	assert.Equal(t, []string{"2023 Q4", "2024 Q1"}, quarters.GetLabels(), "Quarters should be sorted chronologically")
+	assert.Equal(t, []report.Data{
+		{IsInt: false, StringValue: "0 feat, 0 fix, 0 other, 1 non-conforming (features per fix: -)"},
+		{IsInt: false, StringValue: "3 feat, 1 fix, 1 other, 1 non-conforming (features per fix: 3.00)"},
+	}, quarters.GetData())
+}
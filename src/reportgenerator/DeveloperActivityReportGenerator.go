@@ -0,0 +1,114 @@
+package reportgenerator
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+)
+
+// DeveloperActivity sums up the commits and the lines changed by a developer, the shares
+// of the commits and of their lines with CoAuthorCreditFractional.
+type DeveloperActivity struct {
+    Commits float64
+    Added float64
+    Deleted float64
+    FirstCommit time.Time
+    LastCommit time.Time
+}
+
+// DeveloperActivityReportGenerator puts the commits, lines added and deleted and the first and last
+// commit of every developer side by side. Merge commits are ignored, like in the commit size report.
+type DeveloperActivityReportGenerator struct {
+    Identity Identity // signature giving the date of the commits
+    CoAuthorCredit CoAuthorCredit
+    Authors *Authors // resolves the co-authors through the .mailmap file
+
+    ActivityMap map[string]*DeveloperActivity // developer name => activity
+
+    mu sync.Mutex
+}
+
+func (r *DeveloperActivityReportGenerator) StatsIterationStep(c *object.Commit, a Author, stats object.FileStats)  {
+    if c.NumParents() > 1 {
+        return
+    }
+    when := r.Identity.Signature(c).When.Local()
+    added, deleted := 0, 0
+    for _, file := range stats {
+        added += file.Addition
+        deleted += file.Deletion
+    }
+    credits := r.CoAuthorCredit.Credits(c, a, r.Authors)
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.ActivityMap == nil {
+        r.ActivityMap = make(map[string]*DeveloperActivity)
+    }
+    for _, credit := range credits {
+        activity, exists := r.ActivityMap[credit.Author.Name]
+        if !exists {
+            activity = &DeveloperActivity{FirstCommit: when, LastCommit: when}
+            r.ActivityMap[credit.Author.Name] = activity
+        }
+        activity.Commits += credit.Share
+        activity.Added += float64(added) * credit.Share
+        activity.Deleted += float64(deleted) * credit.Share
+        if when.Before(activity.FirstCommit) {
+            activity.FirstCommit = when
+        }
+        if when.After(activity.LastCommit) {
+            activity.LastCommit = when
+        }
+    }
+}
+
+func (rg *DeveloperActivityReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+
+    total := 0.0
+    names := make([]string, 0, len(rg.ActivityMap))
+    for name, activity := range rg.ActivityMap {
+        names = append(names, name)
+        total += activity.Commits
+    }
+    sort.Slice(names, func(i, j int) bool {
+        if rg.ActivityMap[names[i]].Commits != rg.ActivityMap[names[j]].Commits {
+            return rg.ActivityMap[names[i]].Commits > rg.ActivityMap[names[j]].Commits
+        }
+        return names[i] < names[j]
+    })
+
+    var rows [][]report.Value
+    for _, name := range names {
+        activity := rg.ActivityMap[name]
+        rows = append(rows, []report.Value{
+            report.IntValue(int(math.Round(activity.Commits))),
+            report.PercentageValue(activity.Commits * 100 / total),
+            report.IntValue(int(math.Round(activity.Added))),
+            report.IntValue(int(math.Round(activity.Deleted))),
+            report.DateValue(activity.FirstCommit),
+            report.DateValue(activity.LastCommit),
+        })
+    }
+
+    r := report.Report{}
+    r.SetLabels(names)
+    r.SetColumns([]report.Column{
+        {Name: "Commits", Type: report.TypeInt},
+        {Name: "Share", Type: report.TypePercentage},
+        {Name: "Lines added", Type: report.TypeInt},
+        {Name: "Lines deleted", Type: report.TypeInt},
+        {Name: "First commit", Type: report.TypeDate},
+        {Name: "Last commit", Type: report.TypeDate},
+    })
+    r.SetRows(rows)
+    r.SetTitle("Developer activity")
+    r.SetReportType("table")
+    return r
+}
@@ -0,0 +1,74 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeveloperActivityReportGenerator_GetReport(t *testing.T) {
+	generator := DeveloperActivityReportGenerator{}
+	alice := Author{Name: "Alice"}
+	bob := Author{Name: "Bob"}
+	day := func(name string, date int) *object.Commit {
+		return createMockCommit(name, name+"@example.com", time.Date(2024, time.January, date, 12, 0, 0, 0, time.Local))
+	}
+
+	generator.StatsIterationStep(day("Bob", 5), bob, object.FileStats{{Name: "a.go", Addition: 10, Deletion: 2}})
+	generator.StatsIterationStep(day("Alice", 9), alice, object.FileStats{{Name: "a.go", Addition: 3}, {Name: "b.go", Deletion: 7}})
+	generator.StatsIterationStep(day("Alice", 2), alice, object.FileStats{{Name: "a.go", Addition: 100}})
+	generator.StatsIterationStep(day("Alice", 4), alice, nil)
+	merge := day("Bob", 20)
+	merge.ParentHashes = []plumbing.Hash{plumbing.NewHash("1"), plumbing.NewHash("2")}
+	generator.StatsIterationStep(merge, bob, object.FileStats{{Name: "a.go", Addition: 500}})
+
+	r := generator.GetReport()
+	assert.Equal(t, "Developer activity", r.GetTitle())
+	assert.Equal(t, "table", r.GetReportType())
+	assert.Equal(t, []string{"Alice", "Bob"}, r.GetLabels())
+
+	columns := r.GetColumns()
+	require.Len(t, columns, 6)
+	assert.Equal(t, report.Column{Name: "Share", Type: report.TypePercentage}, columns[1])
+
+	rows := r.GetRows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, []report.Value{
+		report.IntValue(3),
+		report.PercentageValue(75),
+		report.IntValue(103),
+		report.IntValue(7),
+		report.DateValue(time.Date(2024, time.January, 2, 12, 0, 0, 0, time.Local)),
+		report.DateValue(time.Date(2024, time.January, 9, 12, 0, 0, 0, time.Local)),
+	}, rows[0])
+	assert.Equal(t, "2024-01-05", rows[1][5].Format(""), "The merge commit should be ignored")
+}
+
+func TestDeveloperActivityReportGenerator_CoAuthorCredit(t *testing.T) {
+	authorA := Author{Name: "Author A", Emails: map[string]bool{"authora@example.com": true}}
+	paired := createMockCoAuthoredCommit("Pair\n\nCo-authored-by: Author B <authorb@example.com>\n")
+	stats := object.FileStats{{Name: "a.go", Addition: 10, Deletion: 4}}
+
+	full := DeveloperActivityReportGenerator{CoAuthorCredit: CoAuthorCreditFull}
+	full.StatsIterationStep(paired, authorA, stats)
+	assert.Equal(t, DeveloperActivity{Commits: 1, Added: 10, Deleted: 4, FirstCommit: paired.Author.When.Local(), LastCommit: paired.Author.When.Local()}, *full.ActivityMap["Author B"], "Co-authors should get the whole commit")
+
+	fractional := DeveloperActivityReportGenerator{CoAuthorCredit: CoAuthorCreditFractional}
+	fractional.StatsIterationStep(paired, authorA, stats)
+	fractional.StatsIterationStep(createMockCoAuthoredCommit("Solo"), authorA, stats)
+	assert.Equal(t, 1.5, fractional.ActivityMap["Author A"].Commits)
+	assert.Equal(t, 5.0, fractional.ActivityMap["Author B"].Added, "The lines should be split like the commit")
+
+	r := fractional.GetReport()
+	assert.Equal(t, []string{"Author A", "Author B"}, r.GetLabels())
+	assert.Equal(t, []report.Value{report.IntValue(2), report.PercentageValue(75), report.IntValue(15), report.IntValue(6)}, r.GetRows()[0][:4])
+
+	authorOnly := DeveloperActivityReportGenerator{}
+	authorOnly.StatsIterationStep(paired, authorA, stats)
+	assert.Equal(t, []string{"Author A"}, authorOnly.GetReport().GetLabels())
+}
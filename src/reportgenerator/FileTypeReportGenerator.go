@@ -3,17 +3,31 @@ package reportgenerator
 import (
 	"path/filepath"
 	"sort"
+	"sync"
 
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/linguist"
 	"github.com/k1-end/git-reports/src/report"
 )
 
 type FileTypeReportGenerator struct {
     FileTypeMap  map[string]int
+    ByLanguage bool // group the files by detected language instead of by extension
+
+    mu sync.Mutex
 }
 
-func (r FileTypeReportGenerator) FileIterationStep(f *object.File)  {
+func (r *FileTypeReportGenerator) FileIterationStep(f *object.File)  {
     mtype := filepath.Ext(f.Name)
+    if r.ByLanguage {
+        language, err := linguist.DetectFile(f)
+        if err != nil {
+            language = linguist.OtherLanguage
+        }
+        mtype = language
+    }
+    r.mu.Lock()
+    defer r.mu.Unlock()
     if _, exists := r.FileTypeMap[mtype]; !exists {
         r.FileTypeMap[mtype] = int(f.Size)
     } else {
@@ -21,7 +35,9 @@ func (r FileTypeReportGenerator) FileIterationStep(f *object.File)  {
     }
 }
 
-func (rg FileTypeReportGenerator) GetReport() report.Report {
+func (rg *FileTypeReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
 
     mimeTypes := make([]string, 0, len(rg.FileTypeMap))
 
@@ -44,9 +60,13 @@ func (rg FileTypeReportGenerator) GetReport() report.Report {
         data = append(data, report.Data{IsInt: true, IntValue: v})
     }
     r := report.Report{}
-    r.SetTitle("File Types (KB)")
+    if rg.ByLanguage {
+        r.SetTitle("Languages (KB)")
+    } else {
+        r.SetTitle("File Types (KB)")
+    }
     r.SetData(data)
     r.SetLabels(labels)
-    r.SetReportType("bar_chart")
+    r.SetReportType("pie_chart")
     return r
 }
@@ -69,7 +69,7 @@ func TestFileTypeReportGenerator_GetReport(t *testing.T) {
 	assert.Equal(t, "File Types (KB)", r.GetTitle(), "Report title should be 'File Types (KB)'")
 
 	// Check report type
-	assert.Equal(t, "bar_chart", r.GetReportType(), "Report type should be 'bar_chart'")
+	assert.Equal(t, "pie_chart", r.GetReportType(), "Report type should be 'pie_chart'")
 
 	// Check labels (sorted by size, filtered for > 1000)
 	expectedLabels := []string{".jpg", ".html", ".txt", ".go"} // Corrected order
@@ -96,7 +96,7 @@ func TestFileTypeReportGenerator_GetReport_EmptyMap(t *testing.T) {
 	assert.Equal(t, "File Types (KB)", r.GetTitle(), "Report title should be 'File Types (KB)'")
 
 	// Check report type
-	assert.Equal(t, "bar_chart", r.GetReportType(), "Report type should be 'bar_chart'")
+	assert.Equal(t, "pie_chart", r.GetReportType(), "Report type should be 'pie_chart'")
 
 	// Check labels (empty)
 	assert.Empty(t, r.GetLabels(), "Report labels should be empty")
@@ -105,3 +105,27 @@ func TestFileTypeReportGenerator_GetReport_EmptyMap(t *testing.T) {
 	assert.Empty(t, r.GetData(), "Report data should be empty")
 }
 
+
+func TestFileTypeReportGenerator_FileIterationStep_ByLanguage(t *testing.T) {
+	generator := FileTypeReportGenerator{
+		FileTypeMap: make(map[string]int),
+		ByLanguage:  true,
+	}
+
+	files := []*object.File{
+		{Name: "cmd/main.go", Mode: 0644, Blob: object.Blob{Size: 1000}},
+		{Name: "Makefile", Mode: 0644, Blob: object.Blob{Size: 300}},
+		{Name: "docs/GNUmakefile", Mode: 0644, Blob: object.Blob{Size: 200}},
+		{Name: "src/report.go", Mode: 0644, Blob: object.Blob{Size: 500}},
+	}
+	for _, f := range files {
+		generator.FileIterationStep(f)
+	}
+
+	assert.Equal(t, 1500, generator.FileTypeMap["Go"], "Go files should be grouped together")
+	assert.Equal(t, 500, generator.FileTypeMap["Makefile"], "Extensionless makefiles should be detected by name")
+	assert.NotContains(t, generator.FileTypeMap, "", "No file should fall into the empty extension bucket")
+
+	r := generator.GetReport()
+	assert.Equal(t, "Languages (KB)", r.GetTitle(), "Report title should be 'Languages (KB)'")
+}
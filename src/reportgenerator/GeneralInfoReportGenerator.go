@@ -1,6 +1,11 @@
 package reportgenerator
 
 import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/k1-end/git-reports/src/report"
 	"golang.org/x/text/language"
@@ -12,27 +17,40 @@ type GeneralInfoReportGenerator struct {
     CommitsNo int
     ProjectSize uint64
     FilesNo int
+    Shallow bool // the repository is a shallow clone, its history is truncated
+    CoAuthorCredit CoAuthorCredit // co-authors are contributors too unless CoAuthorCreditAuthorOnly
+    Authors *Authors // resolves the co-authors through the .mailmap file
 
-    contributors map[string]bool // email => true
+    contributors map[string]bool // name => true
+    mu sync.Mutex
 }
 
 func (r *GeneralInfoReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    credits := r.CoAuthorCredit.Credits(c, a, r.Authors)
+    r.mu.Lock()
+    defer r.mu.Unlock()
     if r.contributors == nil {
         r.contributors = make(map[string]bool)
     }
-	if _, exists := r.contributors[a.Name]; !exists {
-        r.contributors[a.Name] = true
-        r.ContributorsNo += 1
+    for _, credit := range credits {
+        if _, exists := r.contributors[credit.Author.Name]; !exists {
+            r.contributors[credit.Author.Name] = true
+            r.ContributorsNo += 1
+        }
     }
     r.CommitsNo += 1
 }
 
 func (r *GeneralInfoReportGenerator) FileIterationStep(f *object.File)  {
+    r.mu.Lock()
+    defer r.mu.Unlock()
     r.FilesNo += 1
     r.ProjectSize += uint64(f.Size)
 }
 
-func (rg GeneralInfoReportGenerator) GetReport() report.Report {
+func (rg *GeneralInfoReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
     keys := []string{"Number of contributors", "Number of commits", "Project size", "Number of files"}
 
 
@@ -42,6 +60,10 @@ func (rg GeneralInfoReportGenerator) GetReport() report.Report {
     data = append(data, report.Data{IsInt: false, StringValue: p.Sprintf("%d", rg.CommitsNo)})
     data = append(data, report.Data{IsInt: false, StringValue: p.Sprintf("%d", rg.ProjectSize / 1000) + " KB"})
     data = append(data, report.Data{IsInt: false, StringValue: p.Sprintf("%d", rg.FilesNo)})
+    if rg.Shallow {
+        keys = append(keys, "History")
+        data = append(data, report.Data{IsInt: false, StringValue: "Partial (shallow clone)"})
+    }
 
     r := report.Report{}
     r.SetLabels(keys)
@@ -50,3 +72,54 @@ func (rg GeneralInfoReportGenerator) GetReport() report.Report {
     r.SetReportType("table")
     return r
 }
+
+// CompareGeneralInfo returns the commits and contributors of two periods side by side, and the
+// contributors who only committed in one of them.
+func CompareGeneralInfo(previous *GeneralInfoReportGenerator, current *GeneralInfoReportGenerator, previousName string, currentName string) []report.Report {
+    previous.mu.Lock()
+    defer previous.mu.Unlock()
+    current.mu.Lock()
+    defer current.mu.Unlock()
+
+    var newContributors, lostContributors []string
+    for name := range current.contributors {
+        if !previous.contributors[name] {
+            newContributors = append(newContributors, name)
+        }
+    }
+    for name := range previous.contributors {
+        if !current.contributors[name] {
+            lostContributors = append(lostContributors, name)
+        }
+    }
+    sort.Strings(newContributors)
+    sort.Strings(lostContributors)
+
+    summary := report.Report{}
+    summary.SetLabels([]string{"Number of commits", "Number of contributors"})
+    summary.SetColumns([]report.Column{{Name: previousName, Type: report.TypeInt}, {Name: currentName, Type: report.TypeInt}, {Name: "Change", Type: report.TypeChange}})
+    summary.SetRows([][]report.Value{
+        {report.IntValue(previous.CommitsNo), report.IntValue(current.CommitsNo), report.ChangeValue(previous.CommitsNo, current.CommitsNo)},
+        {report.IntValue(previous.ContributorsNo), report.IntValue(current.ContributorsNo), report.ChangeValue(previous.ContributorsNo, current.ContributorsNo)},
+    })
+    summary.SetTitle("General Info")
+    summary.SetReportType("table")
+
+    contributors := report.Report{}
+    contributors.SetLabels([]string{"New contributors", "Lost contributors"})
+    contributors.SetData([]report.Data{
+        {StringValue: contributorNames(newContributors)},
+        {StringValue: contributorNames(lostContributors)},
+    })
+    contributors.SetTitle("New and lost contributors")
+    contributors.SetReportType("table")
+    return []report.Report{summary, contributors}
+}
+
+// contributorNames lists the names with their number, e.g. "2: Alice, Bob".
+func contributorNames(names []string) string {
+    if len(names) == 0 {
+        return "0"
+    }
+    return strconv.Itoa(len(names)) + ": " + strings.Join(names, ", ")
+}
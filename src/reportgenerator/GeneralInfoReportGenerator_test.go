@@ -124,3 +124,61 @@ func TestGeneralInfoReportGenerator_GetReport_ZeroValues(t *testing.T) {
 	}
 	assert.Equal(t, expectedData, r.GetData(), "Report data should be all zeros")
 }
+
+func TestGeneralInfoReportGenerator_GetReport_Shallow(t *testing.T) {
+	generator := GeneralInfoReportGenerator{CommitsNo: 1, Shallow: true}
+
+	r := generator.GetReport()
+
+	labels := r.GetLabels()
+	assert.Equal(t, "History", labels[len(labels)-1], "Shallow clones should have a history row")
+	assert.Equal(t, report.Data{IsInt: false, StringValue: "Partial (shallow clone)"}, r.GetData()[len(labels)-1], "History should be marked as partial")
+}
+
+func TestGeneralInfoReportGenerator_CoAuthorCredit(t *testing.T) {
+	authorA := Author{Name: "Author A", Emails: map[string]bool{"authora@example.com": true}}
+	paired := createMockCoAuthoredCommit("Pair\n\nCo-authored-by: Author B <authorb@example.com>\n")
+
+	authorOnly := GeneralInfoReportGenerator{}
+	authorOnly.LogIterationStep(paired, authorA)
+	assert.Equal(t, 1, authorOnly.ContributorsNo, "Co-authors should not be contributors with author only credit")
+
+	full := GeneralInfoReportGenerator{CoAuthorCredit: CoAuthorCreditFull}
+	full.LogIterationStep(paired, authorA)
+	assert.Equal(t, 2, full.ContributorsNo, "Co-authors should be contributors")
+	assert.Equal(t, 1, full.CommitsNo, "Co-authored commits should be counted once")
+}
+
+func TestCompareGeneralInfo(t *testing.T) {
+	previous := GeneralInfoReportGenerator{}
+	current := GeneralInfoReportGenerator{}
+	commit := createMockCommit("Author A", "authora@example.com", time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC))
+	for _, name := range []string{"Alice", "Bob", "Bob", "Carol"} {
+		previous.LogIterationStep(commit, Author{Name: name})
+	}
+	for _, name := range []string{"Alice", "Dave", "Alice", "Erin", "Alice"} {
+		current.LogIterationStep(commit, Author{Name: name})
+	}
+
+	reports := CompareGeneralInfo(&previous, &current, "April", "May")
+	assert.Len(t, reports, 2)
+
+	summary := reports[0]
+	assert.Equal(t, "General Info", summary.GetTitle())
+	assert.Equal(t, []string{"Number of commits", "Number of contributors"}, summary.GetLabels())
+	assert.Equal(t, "April", summary.GetColumns()[0].Name)
+	assert.Equal(t, [][]report.Value{
+		{report.IntValue(4), report.IntValue(5), report.ChangeValue(4, 5)},
+		{report.IntValue(3), report.IntValue(3), report.ChangeValue(3, 3)},
+	}, summary.GetRows())
+
+	contributors := reports[1]
+	assert.Equal(t, "New and lost contributors", contributors.GetTitle())
+	assert.Equal(t, []report.Data{{StringValue: "2: Dave, Erin"}, {StringValue: "2: Bob, Carol"}}, contributors.GetData())
+
+	same := GeneralInfoReportGenerator{}
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		same.LogIterationStep(commit, Author{Name: name})
+	}
+	assert.Equal(t, []report.Data{{StringValue: "0"}, {StringValue: "0"}}, CompareGeneralInfo(&previous, &same, "April", "May")[1].GetData())
+}
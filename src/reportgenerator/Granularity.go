@@ -0,0 +1,112 @@
+package reportgenerator
+
+import (
+	"fmt"
+	"time"
+)
+
+// Granularity is the length of the periods of the reports over time.
+type Granularity string
+
+const (
+    GranularityDay     Granularity = "day"
+    GranularityWeek    Granularity = "week" // ISO weeks, starting on Monday
+    GranularityMonth   Granularity = "month"
+    GranularityQuarter Granularity = "quarter"
+    GranularityYear    Granularity = "year"
+)
+
+// Granularities lists the valid granularities, from the finest to the coarsest.
+var Granularities = []Granularity{GranularityDay, GranularityWeek, GranularityMonth, GranularityQuarter, GranularityYear}
+
+// String returns the name of the granularity, the zero value is a month.
+func (g Granularity) String() string {
+    if g == "" {
+        return string(GranularityMonth)
+    }
+    return string(g)
+}
+
+// Start returns the beginning of the period containing t, in the location of t.
+// The zero value is GranularityMonth.
+func (g Granularity) Start(t time.Time) time.Time {
+    year, month, day := t.Date()
+    switch g {
+    case GranularityDay:
+        return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+    case GranularityWeek:
+        daysSinceMonday := (int(t.Weekday()) + 6) % 7
+        return time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, t.Location())
+    case GranularityQuarter:
+        return time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, t.Location())
+    case GranularityYear:
+        return time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+    default:
+        return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+    }
+}
+
+// Next returns the beginning of the period following the one starting at start.
+func (g Granularity) Next(start time.Time) time.Time {
+    switch g {
+    case GranularityDay:
+        return start.AddDate(0, 0, 1)
+    case GranularityWeek:
+        return start.AddDate(0, 0, 7)
+    case GranularityQuarter:
+        return start.AddDate(0, 3, 0)
+    case GranularityYear:
+        return start.AddDate(1, 0, 0)
+    default:
+        return start.AddDate(0, 1, 0)
+    }
+}
+
+// Label names the period starting at start, e.g. "2024-01-31", "2024-W05", "2024-01", "2024 Q1" or "2024".
+func (g Granularity) Label(start time.Time) string {
+    switch g {
+    case GranularityDay:
+        return start.Format(time.DateOnly)
+    case GranularityWeek:
+        year, week := start.ISOWeek()
+        return fmt.Sprintf("%d-W%02d", year, week)
+    case GranularityQuarter:
+        return fmt.Sprintf("%d Q%d", start.Year(), (int(start.Month())-1)/3+1)
+    case GranularityYear:
+        return start.Format("2006")
+    default:
+        return start.Format("2006-01")
+    }
+}
+
+// Periods returns the beginning of every period from the one containing first to the one containing last.
+func (g Granularity) Periods(first time.Time, last time.Time) []time.Time {
+    var periods []time.Time
+    for start := g.Start(first); !start.After(last); start = g.Next(start) {
+        periods = append(periods, start)
+    }
+    return periods
+}
+
+// periodLabels returns every period from the first to the last key of counts, the ones
+// missing from counts too, and their labels.
+func periodLabels(g Granularity, counts map[time.Time]int) ([]string, []time.Time) {
+    var first, last time.Time
+    for period := range counts {
+        if first.IsZero() || period.Before(first) {
+            first = period
+        }
+        if period.After(last) {
+            last = period
+        }
+    }
+    if first.IsZero() {
+        return nil, nil
+    }
+    periods := g.Periods(first, last)
+    labels := make([]string, len(periods))
+    for i, period := range periods {
+        labels[i] = g.Label(period)
+    }
+    return labels, periods
+}
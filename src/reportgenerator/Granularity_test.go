@@ -0,0 +1,63 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGranularity(t *testing.T) {
+	// Wednesday 2024-01-03
+	date := time.Date(2024, time.January, 3, 15, 30, 0, 0, time.UTC)
+	testCases := []struct {
+		granularity   Granularity
+		expectedStart time.Time
+		expectedNext  time.Time
+		expectedLabel string
+	}{
+		{GranularityDay, time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, time.January, 4, 0, 0, 0, 0, time.UTC), "2024-01-03"},
+		{GranularityWeek, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC), "2024-W01"},
+		{GranularityMonth, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), "2024-01"},
+		{GranularityQuarter, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), "2024 Q1"},
+		{GranularityYear, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), "2024"},
+		{"", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), "2024-01"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.granularity), func(t *testing.T) {
+			start := tc.granularity.Start(date)
+			assert.Equal(t, tc.expectedStart, start)
+			assert.Equal(t, tc.expectedNext, tc.granularity.Next(start))
+			assert.Equal(t, tc.expectedLabel, tc.granularity.Label(start))
+		})
+	}
+}
+
+func TestGranularity_Start(t *testing.T) {
+	// Sunday belongs to the week started on the previous Monday
+	sunday := time.Date(2024, time.March, 10, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), GranularityWeek.Start(sunday))
+	assert.Equal(t, time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC), GranularityQuarter.Start(time.Date(2024, time.September, 30, 0, 0, 0, 0, time.UTC)))
+
+	// The ISO year of the last days of December may be the next one
+	assert.Equal(t, "2025-W01", GranularityWeek.Label(GranularityWeek.Start(time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC))))
+
+	// Buckets follow the time zone of the date
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err == nil {
+		newYear := time.Date(2023, time.December, 31, 23, 30, 0, 0, time.UTC).In(berlin)
+		assert.Equal(t, "2024-01", GranularityMonth.Label(GranularityMonth.Start(newYear)))
+	}
+}
+
+func TestGranularity_Periods(t *testing.T) {
+	first := time.Date(2024, time.January, 20, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC)
+	periods := GranularityMonth.Periods(first, last)
+	assert.Equal(t, []time.Time{
+		time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+	}, periods)
+}
@@ -0,0 +1,63 @@
+package reportgenerator
+
+import (
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Identity selects which signature of a commit the reports are about: the developer who
+// wrote the change or the one who committed it, e.g. a maintainer applying a patch.
+type Identity string
+
+const (
+    IdentityAuthor    Identity = "author"
+    IdentityCommitter Identity = "committer"
+)
+
+// Signature returns the signature of the commit for the identity. The zero value is IdentityAuthor.
+func (i Identity) Signature(c *object.Commit) object.Signature {
+    if i == IdentityCommitter {
+        return c.Committer
+    }
+    return c.Author
+}
+
+// Authors resolves the emails found in commits to authors, merging the emails listed on
+// the same line of the .mailmap file. It is safe for concurrent use.
+type Authors struct {
+    byEmail map[string]*Author
+    mu      sync.Mutex
+}
+
+// NewAuthors creates a resolver knowing the authors of the .mailmap file.
+func NewAuthors(mailmap []Author) *Authors {
+    authors := &Authors{byEmail: make(map[string]*Author)}
+    for i := range mailmap {
+        for email := range mailmap[i].Emails {
+            authors.byEmail[email] = &mailmap[i]
+        }
+    }
+    return authors
+}
+
+// Resolve returns the author of the signature. Unknown emails become a new author named
+// after the signature. The same pointer is returned for all the emails of an author.
+func (a *Authors) Resolve(s object.Signature) *Author {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+    if author, exists := a.byEmail[s.Email]; exists {
+        return author
+    }
+    author := &Author{Name: s.Name, Emails: map[string]bool{s.Email: true}}
+    a.byEmail[s.Email] = author
+    return author
+}
+
+// Lookup returns the author of an email if it was resolved before or listed in the .mailmap file.
+func (a *Authors) Lookup(email string) (*Author, bool) {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+    author, exists := a.byEmail[email]
+    return author, exists
+}
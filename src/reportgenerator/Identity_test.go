@@ -0,0 +1,39 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentity_Signature(t *testing.T) {
+	commit := createMockCommit("Author A", "authora@example.com", time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC))
+	commit.Committer = object.Signature{Name: "Author B", Email: "authorb@example.com", When: time.Date(2024, time.January, 16, 10, 0, 0, 0, time.UTC)}
+
+	assert.Equal(t, commit.Author, IdentityAuthor.Signature(commit), "Author identity should give the author")
+	assert.Equal(t, commit.Committer, IdentityCommitter.Signature(commit), "Committer identity should give the committer")
+	assert.Equal(t, commit.Author, Identity("").Signature(commit), "Zero value should give the author")
+}
+
+func TestAuthors_Resolve(t *testing.T) {
+	authors := NewAuthors([]Author{
+		{Name: "Author A", Emails: map[string]bool{"authora@example.com": true, "a@work.example.com": true}},
+	})
+
+	personal := authors.Resolve(object.Signature{Name: "A", Email: "authora@example.com"})
+	work := authors.Resolve(object.Signature{Name: "A at work", Email: "a@work.example.com"})
+	assert.Same(t, personal, work, "Emails of the same mailmap line should resolve to the same author")
+	assert.Equal(t, "Author A", work.Name, "Mailmap name should be used")
+
+	unknown := authors.Resolve(object.Signature{Name: "Author B", Email: "authorb@example.com"})
+	assert.Equal(t, Author{Name: "Author B", Emails: map[string]bool{"authorb@example.com": true}}, *unknown, "Unknown emails should become a new author")
+	assert.Same(t, unknown, authors.Resolve(object.Signature{Name: "B", Email: "authorb@example.com"}), "Resolved authors should be remembered")
+
+	found, exists := authors.Lookup("authorb@example.com")
+	assert.True(t, exists, "Resolved emails should be found")
+	assert.Same(t, unknown, found)
+	_, exists = authors.Lookup("authorc@example.com")
+	assert.False(t, exists, "Unknown emails should not be found")
+}
@@ -0,0 +1,173 @@
+package reportgenerator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/report"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// maxReferencedIssues limits the bars of the most referenced issues.
+const maxReferencedIssues = 20
+
+// DefaultIssuePatterns match GitHub style references like #123 and Jira style keys like PROJ-456.
+var DefaultIssuePatterns = []string{`#\d+\b`, `\b[A-Z][A-Z0-9]+-\d+\b`}
+
+// notIssueKeys are the prefixes of standards, encodings and algorithms written like issue keys,
+// e.g. UTF-8, SHA-256, ISO-8601 or RFC-1234. They are left out of the matches of DefaultIssuePatterns,
+// a pattern given by the user matches what it was written for, CVE-2024-1234 included.
+var notIssueKeys = map[string]bool{
+    "AES": true, "CVE": true, "CWE": true, "ECMA": true, "IEC": true, "IEEE": true, "ISO": true,
+    "RFC": true, "RSA": true, "SHA": true, "UCS": true, "UTF": true,
+}
+
+// IssueReferencesReportGenerator reports how many commits reference an issue of the tracker.
+// The whole message is searched, so trailers like "Fixes #12" count. Merge commits are ignored,
+// their subject references the merged pull request.
+type IssueReferencesReportGenerator struct {
+    CommitsNo int
+    LinkedNo int
+    IssuesMap map[string]int // issue => commits referencing it
+    CommitsPerDevMap map[string]int
+    LinkedPerDevMap map[string]int
+
+    patterns []*regexp.Regexp
+    defaults []bool // whether the pattern of the same index is one of DefaultIssuePatterns
+    mu sync.Mutex
+}
+
+// NewIssueReferencesReportGenerator compiles the regular expressions matching the issue keys.
+// When a pattern has a capture group, the first group is the key, otherwise the whole match is.
+func NewIssueReferencesReportGenerator(patterns []string) (*IssueReferencesReportGenerator, error) {
+    rg := &IssueReferencesReportGenerator{
+        IssuesMap: make(map[string]int),
+        CommitsPerDevMap: make(map[string]int),
+        LinkedPerDevMap: make(map[string]int),
+    }
+    for _, pattern := range patterns {
+        compiled, err := regexp.Compile(pattern)
+        if err != nil {
+            return nil, fmt.Errorf("invalid issue pattern %q: %w", pattern, err)
+        }
+        rg.patterns = append(rg.patterns, compiled)
+        rg.defaults = append(rg.defaults, slices.Contains(DefaultIssuePatterns, pattern))
+    }
+    return rg, nil
+}
+
+// Issues returns the distinct issue keys referenced by a commit message, in order of appearance.
+func (rg *IssueReferencesReportGenerator) Issues(msg string) []string {
+    var issues []string
+    seen := make(map[string]bool)
+    for i, pattern := range rg.patterns {
+        for _, match := range pattern.FindAllStringSubmatch(msg, -1) {
+            issue := match[0]
+            if len(match) > 1 && match[1] != "" {
+                issue = match[1]
+            }
+            if prefix, _, found := strings.Cut(issue, "-"); found && rg.defaults[i] && notIssueKeys[prefix] {
+                continue
+            }
+            if !seen[issue] {
+                seen[issue] = true
+                issues = append(issues, issue)
+            }
+        }
+    }
+    return issues
+}
+
+func (r *IssueReferencesReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    if c.NumParents() > 1 {
+        return
+    }
+    issues := r.Issues(c.Message)
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.CommitsNo++
+    r.CommitsPerDevMap[a.Name]++
+    if len(issues) == 0 {
+        return
+    }
+    r.LinkedNo++
+    r.LinkedPerDevMap[a.Name]++
+    for _, issue := range issues {
+        r.IssuesMap[issue]++
+    }
+}
+
+// GetReport returns the share of commits referencing an issue, see GetReports for the details.
+func (rg *IssueReferencesReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return rg.summaryReport()
+}
+
+// GetReports returns the share of commits referencing an issue, the most referenced issues
+// and the share per developer.
+func (rg *IssueReferencesReportGenerator) GetReports() []report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    return []report.Report{
+        rg.summaryReport(),
+        countsReport("Most referenced issues", rg.IssuesMap, maxReferencedIssues),
+        rg.developersReport(),
+    }
+}
+
+func (rg *IssueReferencesReportGenerator) summaryReport() report.Report {
+    p := message.NewPrinter(language.English)
+    unlinked := rg.CommitsNo - rg.LinkedNo
+    labels := []string{"Commits referencing an issue", "Commits without a reference", "Referenced issues"}
+    data := []report.Data{
+        {IsInt: false, StringValue: p.Sprintf("%d (%d%%)", rg.LinkedNo, percentage(rg.LinkedNo, rg.CommitsNo))},
+        {IsInt: false, StringValue: p.Sprintf("%d (%d%%)", unlinked, percentage(unlinked, rg.CommitsNo))},
+        {IsInt: false, StringValue: p.Sprintf("%d", len(rg.IssuesMap))},
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetData(data)
+    r.SetTitle("Issue references")
+    r.SetReportType("table")
+    return r
+}
+
+func (rg *IssueReferencesReportGenerator) developersReport() report.Report {
+    names := make([]string, 0, len(rg.CommitsPerDevMap))
+    for name := range rg.CommitsPerDevMap {
+        names = append(names, name)
+    }
+    sort.Slice(names, func(i, j int) bool {
+        if rg.CommitsPerDevMap[names[i]] != rg.CommitsPerDevMap[names[j]] {
+            return rg.CommitsPerDevMap[names[i]] > rg.CommitsPerDevMap[names[j]]
+        }
+        return names[i] < names[j]
+    })
+
+    var rows [][]report.Value
+    for _, name := range names {
+        linked, commits := rg.LinkedPerDevMap[name], rg.CommitsPerDevMap[name]
+        rows = append(rows, []report.Value{report.IntValue(commits), report.IntValue(linked), report.PercentageValue(share(linked, commits))})
+    }
+
+    r := report.Report{}
+    r.SetLabels(names)
+    r.SetColumns([]report.Column{
+        {Name: "Commits", Type: report.TypeInt},
+        {Name: "Linked commits", Type: report.TypeInt},
+        {Name: "Linkage", Type: report.TypePercentage},
+    })
+    r.SetRows(rows)
+    r.SetTitle("Issue references per developer")
+    r.SetReportType("table")
+    return r
+}
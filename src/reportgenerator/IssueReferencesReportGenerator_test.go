@@ -0,0 +1,86 @@
+package reportgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueReferencesReportGenerator_Issues(t *testing.T) {
+	testCases := []struct {
+		patterns    []string
+		message     string
+		expected    []string
+		description string
+	}{
+		{DefaultIssuePatterns, "Fix the login (#12)", []string{"#12"}, "GitHub reference"},
+		{DefaultIssuePatterns, "PROJ-456: add the cache", []string{"PROJ-456"}, "Jira key"},
+		{DefaultIssuePatterns, "Refactor\n\nFixes #12, refs PROJ-8 and #12", []string{"#12", "PROJ-8"}, "References in the body are deduplicated"},
+		{DefaultIssuePatterns, "Build for x86-64 and B-2 bombers", nil, "Not an issue key"},
+		{DefaultIssuePatterns, "Tweak the styles", nil, "No reference"},
+		{DefaultIssuePatterns, "Read the files as UTF-8", nil, "Encoding"},
+		{DefaultIssuePatterns, "Hash the blobs with SHA-256 instead of SHA-1", nil, "Hash algorithm"},
+		{DefaultIssuePatterns, "Print the dates as ISO-8601 (RFC-3339)", nil, "Standards"},
+		{DefaultIssuePatterns, "Bump x/net for CVE-2024-45338, see PROJ-12", []string{"PROJ-12"}, "Vulnerability id next to a key"},
+		{[]string{`(?i)ticket (\d+)`}, "Close Ticket 42", []string{"42"}, "Capture group is the key"},
+		{[]string{`CVE-\d+`}, "Patch CVE-123", []string{"CVE-123"}, "Custom pattern matching a standard prefix"},
+		{[]string{DefaultIssuePatterns[1], `CVE-\d+-\d+`}, "Bump x/net for CVE-2024-45338 and UTF-8", []string{"CVE-2024-45338"}, "Custom pattern next to the default ones"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			generator, err := NewIssueReferencesReportGenerator(tc.patterns)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, generator.Issues(tc.message), "Issues of %q", tc.message)
+		})
+	}
+}
+
+func TestNewIssueReferencesReportGenerator_InvalidPattern(t *testing.T) {
+	_, err := NewIssueReferencesReportGenerator([]string{"#("})
+	assert.ErrorContains(t, err, `invalid issue pattern "#("`)
+}
+
+func TestIssueReferencesReportGenerator_GetReports(t *testing.T) {
+	generator, err := NewIssueReferencesReportGenerator(DefaultIssuePatterns)
+	require.NoError(t, err)
+	now := time.Now()
+	alice := Author{Name: "Alice"}
+	bob := Author{Name: "Bob"}
+
+	generator.LogIterationStep(createMockMessageCommit("Fix the login (#12)", now), alice)
+	generator.LogIterationStep(createMockMessageCommit("PROJ-7: add the cache\n\nRefs #12", now), alice)
+	generator.LogIterationStep(createMockMessageCommit("Tweak the styles", now), alice)
+	generator.LogIterationStep(createMockMessageCommit("Update the README", now), bob)
+	merge := createMockMessageCommit("Merge pull request #13", now)
+	merge.ParentHashes = []plumbing.Hash{plumbing.NewHash("1"), plumbing.NewHash("2")}
+	generator.LogIterationStep(merge, bob)
+
+	assert.Equal(t, 4, generator.CommitsNo)
+	assert.Equal(t, 2, generator.LinkedNo)
+	assert.Equal(t, map[string]int{"#12": 2, "PROJ-7": 1}, generator.IssuesMap)
+
+	reports := generator.GetReports()
+	require.Len(t, reports, 3)
+
+	summary := reports[0]
+	assert.Equal(t, "Issue references", summary.GetTitle())
+	assert.Equal(t, "table", summary.GetReportType())
+	assert.Equal(t, "2 (50%)", summary.GetData()[0].StringValue)
+	assert.Equal(t, "2 (50%)", summary.GetData()[1].StringValue)
+	assert.Equal(t, "2", summary.GetData()[2].StringValue)
+	assert.Equal(t, summary, generator.GetReport())
+
+	issues := reports[1]
+	assert.Equal(t, "bar_chart", issues.GetReportType())
+	assert.Equal(t, []string{"#12", "PROJ-7"}, issues.GetLabels())
+
+	developers := reports[2]
+	assert.Equal(t, []string{"Alice", "Bob"}, developers.GetLabels())
+	assert.Equal(t, []string{"Commits", "Linked commits", "Linkage"}, columnNames(developers))
+	assert.Equal(t, []string{"3", "2", "66.7%"}, formatRow(developers, 0))
+	assert.Equal(t, []string{"1", "0", "0%"}, formatRow(developers, 1))
+}
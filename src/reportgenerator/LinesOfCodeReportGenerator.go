@@ -0,0 +1,108 @@
+package reportgenerator
+
+import (
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/k1-end/git-reports/src/linguist"
+	"github.com/k1-end/git-reports/src/report"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// maxLinesOfCodeFileSize skips the files that are too big to be hand written code (dumps, bundles...).
+const maxLinesOfCodeFileSize = 1 << 20
+
+type LinesOfCodeReportGenerator struct {
+    LinesPerLanguageMap map[string]linguist.LineCounts
+
+    mu sync.Mutex
+}
+
+func (r *LinesOfCodeReportGenerator) FileIterationStep(f *object.File)  {
+    if f.Size > maxLinesOfCodeFileSize {
+        return
+    }
+    reader, err := f.Reader()
+    if err != nil {
+        return
+    }
+    defer reader.Close()
+    content, err := io.ReadAll(reader)
+    if err != nil || linguist.IsBinary(content) {
+        return
+    }
+
+    l := linguist.Detect(f.Name, content)
+    if l == linguist.OtherLanguage {
+        return
+    }
+    fileCounts := linguist.CountLines(l, content)
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    counts := r.LinesPerLanguageMap[l]
+    counts.Add(fileCounts)
+    r.LinesPerLanguageMap[l] = counts
+}
+
+func (rg *LinesOfCodeReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    languages := make([]string, 0, len(rg.LinesPerLanguageMap))
+    for k := range rg.LinesPerLanguageMap {
+        languages = append(languages, k)
+    }
+
+    sort.SliceStable(languages, func(i, j int) bool {
+        if rg.LinesPerLanguageMap[languages[i]].Code == rg.LinesPerLanguageMap[languages[j]].Code {
+            return languages[i] < languages[j]
+        }
+        return rg.LinesPerLanguageMap[languages[i]].Code > rg.LinesPerLanguageMap[languages[j]].Code
+    })
+
+    // Data and prose are not source code, they are listed after the total and left out of it
+    var code, other []string
+    for _, l := range languages {
+        if linguist.LanguageKind(l) == linguist.KindProgramming {
+            code = append(code, l)
+        } else {
+            other = append(other, l)
+        }
+    }
+
+    p := message.NewPrinter(language.English)
+    var data []report.Data
+    var labels []string
+    var total linguist.LineCounts
+    for _, l := range code {
+        counts := rg.LinesPerLanguageMap[l]
+        total.Add(counts)
+        labels = append(labels, l)
+        data = append(data, report.Data{IsInt: false, StringValue: p.Sprintf("%d code, %d comment, %d blank", counts.Code, counts.Comment, counts.Blank)})
+    }
+    if len(code) > 1 {
+        labels = append(labels, "Total")
+        data = append(data, report.Data{IsInt: false, StringValue: p.Sprintf("%d code, %d comment, %d blank", total.Code, total.Comment, total.Blank)})
+    }
+    for _, l := range other {
+        counts := rg.LinesPerLanguageMap[l]
+        kind := "data"
+        if linguist.LanguageKind(l) == linguist.KindProse {
+            kind = "prose"
+        }
+        labels = append(labels, l+" ("+kind+")")
+        data = append(data, report.Data{IsInt: false, StringValue: p.Sprintf("%d lines, %d comment, %d blank", counts.Code, counts.Comment, counts.Blank)})
+    }
+
+    r := report.Report{}
+    r.SetLabels(labels)
+    r.SetData(data)
+    if len(other) > 0 {
+        r.SetNote("The data and prose files are not counted in the total")
+    }
+    r.SetTitle("Lines of code per language")
+    r.SetReportType("table")
+    return r
+}
@@ -0,0 +1,101 @@
+package reportgenerator
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/k1-end/git-reports/src/linguist"
+	"github.com/k1-end/git-reports/src/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createMockFile stores the content as a blob so that the file can be read.
+func createMockFile(t *testing.T, name string, content string) *object.File {
+	storage := memory.NewStorage()
+	obj := storage.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	w, err := obj.Writer()
+	require.NoError(t, err)
+	_, err = w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	hash, err := storage.SetEncodedObject(obj)
+	require.NoError(t, err)
+
+	blob, err := object.GetBlob(storage, hash)
+	require.NoError(t, err)
+	return object.NewFile(name, filemode.Regular, blob)
+}
+
+func TestLinesOfCodeReportGenerator_FileIterationStep(t *testing.T) {
+	generator := LinesOfCodeReportGenerator{
+		LinesPerLanguageMap: make(map[string]linguist.LineCounts),
+	}
+
+	generator.FileIterationStep(createMockFile(t, "main.go", "package main\n\n// comment\nfunc main() {}\n"))
+	generator.FileIterationStep(createMockFile(t, "util/util.go", "package util\n"))
+	generator.FileIterationStep(createMockFile(t, "bin/deploy", "#!/bin/sh\necho deploy\n"))
+	generator.FileIterationStep(createMockFile(t, "logo.png", "\x89PNG\r\n\x1a\n\x00\x00\x00"))
+	generator.FileIterationStep(createMockFile(t, "data.unknown", "some data\n"))
+
+	assert.Equal(t, linguist.LineCounts{Code: 3, Comment: 1, Blank: 1}, generator.LinesPerLanguageMap["Go"], "Go lines should be summed")
+	assert.Equal(t, linguist.LineCounts{Code: 1, Comment: 1}, generator.LinesPerLanguageMap["Shell"], "Script should be detected by its shebang")
+	assert.Len(t, generator.LinesPerLanguageMap, 2, "Binary and unknown files should be skipped")
+}
+
+func TestLinesOfCodeReportGenerator_GetReport(t *testing.T) {
+	generator := LinesOfCodeReportGenerator{
+		LinesPerLanguageMap: map[string]linguist.LineCounts{
+			"Shell": {Code: 20, Comment: 5, Blank: 2},
+			"Go":    {Code: 1500, Comment: 100, Blank: 200},
+		},
+	}
+
+	r := generator.GetReport()
+
+	assert.Equal(t, "Lines of code per language", r.GetTitle(), "Report title should be 'Lines of code per language'")
+	assert.Equal(t, "table", r.GetReportType(), "Report type should be 'table'")
+	assert.Equal(t, []string{"Go", "Shell", "Total"}, r.GetLabels(), "Languages should be sorted by lines of code")
+
+	expectedData := []report.Data{
+		{IsInt: false, StringValue: "1,500 code, 100 comment, 200 blank"},
+		{IsInt: false, StringValue: "20 code, 5 comment, 2 blank"},
+		{IsInt: false, StringValue: "1,520 code, 105 comment, 202 blank"},
+	}
+	assert.Equal(t, expectedData, r.GetData(), "Report data should contain the line counts")
+	assert.Empty(t, r.GetNote())
+}
+
+func TestLinesOfCodeReportGenerator_GetReport_DataAndProse(t *testing.T) {
+	generator := LinesOfCodeReportGenerator{
+		LinesPerLanguageMap: map[string]linguist.LineCounts{
+			"Go":           {Code: 100, Comment: 10, Blank: 20},
+			"Shell":        {Code: 10},
+			"Go Checksums": {Code: 5000},
+			"JSON":         {Code: 300},
+			"Markdown":     {Code: 50, Blank: 10},
+		},
+	}
+
+	r := generator.GetReport()
+
+	assert.Equal(t, []string{"Go", "Shell", "Total", "Go Checksums (data)", "JSON (data)", "Markdown (prose)"}, r.GetLabels(), "Data and prose should be listed after the total")
+	assert.Equal(t, "110 code, 10 comment, 20 blank", r.GetData()[2].StringValue, "The total should only count the code")
+	assert.Equal(t, "5,000 lines, 0 comment, 0 blank", r.GetData()[3].StringValue)
+	assert.Equal(t, "The data and prose files are not counted in the total", r.GetNote())
+}
+
+func TestLinesOfCodeReportGenerator_GetReport_EmptyMap(t *testing.T) {
+	generator := LinesOfCodeReportGenerator{
+		LinesPerLanguageMap: make(map[string]linguist.LineCounts),
+	}
+
+	r := generator.GetReport()
+
+	assert.Empty(t, r.GetLabels(), "Report labels should be empty")
+	assert.Empty(t, r.GetData(), "Report data should be empty")
+}
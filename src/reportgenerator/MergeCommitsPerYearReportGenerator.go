@@ -3,6 +3,8 @@ package reportgenerator
 import (
 	"sort"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/k1-end/git-reports/src/report"
@@ -10,10 +12,29 @@ import (
 
 type MergeCommitsPerYearReportGenerator struct {
     MergeCommitsPerYearMap map[int]int
+    Identity Identity // signature giving the date of the commits
+    Granularity Granularity // periods of the report, GranularityYear when empty
+    MergeCommitsPerPeriodMap map[time.Time]int // beginning of the period => merge commits, used unless the granularity is a year
+
+    mu sync.Mutex
+}
+
+func (r *MergeCommitsPerYearReportGenerator) byYear() bool {
+    return r.Granularity == "" || r.Granularity == GranularityYear
 }
 
-func (r MergeCommitsPerYearReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
-    year, _, _ := c.Author.When.Local().Date()
+func (r *MergeCommitsPerYearReportGenerator) LogIterationStep(c *object.Commit, a Author)  {
+    when := r.Identity.Signature(c).When.Local()
+    year, _, _ := when.Date()
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if c.NumParents() > 1 && !r.byYear() {
+        if r.MergeCommitsPerPeriodMap == nil {
+            r.MergeCommitsPerPeriodMap = make(map[time.Time]int)
+        }
+        r.MergeCommitsPerPeriodMap[r.Granularity.Start(when)]++
+        return
+    }
     if c.NumParents() > 1 {
         if _, exists := r.MergeCommitsPerYearMap[year]; !exists {
             r.MergeCommitsPerYearMap[year] = 1
@@ -23,7 +44,12 @@ func (r MergeCommitsPerYearReportGenerator) LogIterationStep(c *object.Commit, a
     }
 }
 
-func (rg MergeCommitsPerYearReportGenerator) GetReport() report.Report {
+func (rg *MergeCommitsPerYearReportGenerator) GetReport() report.Report {
+    rg.mu.Lock()
+    defer rg.mu.Unlock()
+    if !rg.byYear() {
+        return rg.periodReport()
+    }
     yearsKey := make([]int, 0, len(rg.MergeCommitsPerYearMap))
     for k := range rg.MergeCommitsPerYearMap {
         yearsKey = append(yearsKey, k)
@@ -42,3 +68,18 @@ func (rg MergeCommitsPerYearReportGenerator) GetReport() report.Report {
     r.SetReportType("bar_chart")
     return r
 }
+
+// periodReport returns the merge commits of every period from the first merge to the last one.
+func (rg *MergeCommitsPerYearReportGenerator) periodReport() report.Report {
+    labels, periods := periodLabels(rg.Granularity, rg.MergeCommitsPerPeriodMap)
+    var data []report.Data
+    for _, period := range periods {
+        data = append(data, report.Data{IsInt: true, IntValue: rg.MergeCommitsPerPeriodMap[period]})
+    }
+    r := report.Report{}
+    r.SetTitle("Merge Commits per " + rg.Granularity.String())
+    r.SetLabels(labels)
+    r.SetData(data)
+    r.SetReportType("bar_chart")
+    return r
+}
@@ -98,3 +98,31 @@ func TestMergeCommitsPerYearReportGenerator_GetReport_EmptyMap(t *testing.T) {
 	// Check data (empty)
 	assert.Empty(t, r.GetData(), "Report data should be empty")
 }
+
+func TestMergeCommitsPerYearReportGenerator_Granularity(t *testing.T) {
+	generator := MergeCommitsPerYearReportGenerator{
+		MergeCommitsPerYearMap: make(map[int]int),
+		Granularity:            GranularityMonth,
+	}
+	merge := func(commitTime time.Time) {
+		commit := createMockCommit("Author A", "authora@example.com", commitTime)
+		commit.ParentHashes = []plumbing.Hash{plumbing.NewHash("parent1"), plumbing.NewHash("parent2")}
+		generator.LogIterationStep(commit, Author{Name: "Author A"})
+	}
+	merge(time.Date(2024, time.January, 15, 10, 0, 0, 0, time.Local))
+	merge(time.Date(2024, time.January, 20, 10, 0, 0, 0, time.Local))
+	merge(time.Date(2024, time.March, 1, 10, 0, 0, 0, time.Local))
+	generator.LogIterationStep(createMockCommit("Author A", "authora@example.com", time.Date(2024, time.May, 1, 10, 0, 0, 0, time.Local)), Author{Name: "Author A"})
+
+	assert.Empty(t, generator.MergeCommitsPerYearMap, "The yearly counts are not used")
+
+	r := generator.GetReport()
+	assert.Equal(t, "Merge Commits per month", r.GetTitle())
+	assert.Equal(t, "bar_chart", r.GetReportType())
+	assert.Equal(t, []string{"2024-01", "2024-02", "2024-03"}, r.GetLabels(), "Every month between the first and the last merge is listed")
+	assert.Equal(t, []report.Data{
+		{IsInt: true, IntValue: 2},
+		{IsInt: true, IntValue: 0},
+		{IsInt: true, IntValue: 1},
+	}, r.GetData())
+}
@@ -1,6 +1,7 @@
 package reportgenerator
 
 import (
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/k1-end/git-reports/src/report"
 )
 
@@ -8,8 +9,33 @@ type ReportGenerator interface {
     GetReport() report.Report
 }
 
+// MultiReportGenerator is implemented by the generators giving several views of what they
+// collected. GetReports is used instead of GetReport when it is available.
+type MultiReportGenerator interface {
+    GetReports() []report.Report
+}
+
 type Author struct {
     Name  string
     Emails map[string]bool
 }
 
+
+// LogIterationStepper is implemented by the generators that look at every commit.
+// LogIterationStep is called concurrently by the analysis workers.
+type LogIterationStepper interface {
+    LogIterationStep(c *object.Commit, a Author)
+}
+
+// FileIterationStepper is implemented by the generators that look at every file of the analyzed tree.
+// FileIterationStep is called concurrently by the analysis workers.
+type FileIterationStepper interface {
+    FileIterationStep(f *object.File)
+}
+
+// StatsIterationStepper is implemented by the generators that need the lines added and deleted
+// by every commit. Computing them diffs every commit, so they are only asked for when needed.
+// StatsIterationStep is called concurrently by the analysis workers.
+type StatsIterationStepper interface {
+    StatsIterationStep(c *object.Commit, a Author, stats object.FileStats)
+}
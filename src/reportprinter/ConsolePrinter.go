@@ -3,9 +3,11 @@ package reportprinter
 import (
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/k1-end/git-reports/src/report"
@@ -150,20 +152,173 @@ func (p ConsolePrinter) printBarChart(c report.Report) {
 	_ = pterm.DefaultBarChart.WithBars(barData).WithHorizontal().WithWidth(90).WithShowValue().Render()
 }
 
+// linePlotHeight and linePlotWidth are the number of rows and columns of the console line charts,
+// the pie charts are as wide.
+const linePlotHeight = 10
+const linePlotWidth = 90
+
+// lineMarkers draw the series of the line charts and areaFills the ones of the stacked areas.
+// The symbols tell the series apart when the terminal has no colors.
+var lineMarkers = []rune{'•', '×', '○', '◆', '◇', '▲'}
+var areaFills = []rune{'█', '▓', '▒', '░', '▚', '■'}
+var seriesColors = []pterm.Color{pterm.FgGreen, pterm.FgYellow, pterm.FgCyan, pterm.FgMagenta, pterm.FgRed, pterm.FgBlue}
+
+// linePlot draws the series with the y axis on the left, the first and last labels below and
+// a legend when there are several series. Stacked series are drawn as areas piled up from the
+// first series. When there are more labels than columns, a column shows the average of its values.
+func linePlot(labels []string, series []report.Series, stacked bool, width int, height int) []string {
+    if len(labels) == 0 || len(series) == 0 {
+        return nil
+    }
+    symbols := lineMarkers
+    if stacked {
+        symbols = areaFills
+    }
+
+    // points[s][c] is the value of the series s in the column c, on top of the previous series when stacked
+    columns := min(len(labels), width)
+    points := make([][]float64, len(series))
+    top := 1
+    for s := range series {
+        points[s] = make([]float64, columns)
+        for c := range points[s] {
+            from, to := c*len(labels)/columns, (c+1)*len(labels)/columns
+            sum := 0
+            for i := from; i < to && i < len(series[s].Values); i++ {
+                sum += series[s].Values[i]
+            }
+            points[s][c] = float64(sum) / float64(to-from)
+            if stacked && s > 0 {
+                points[s][c] += points[s-1][c]
+            }
+            top = max(top, int(math.Ceil(points[s][c])))
+        }
+    }
+    row := func(value float64) int {
+        return int(math.Round((1 - value/float64(top)) * float64(height-1)))
+    }
+
+    grid := make([][]rune, height)
+    for r := range grid {
+        grid[r] = []rune(strings.Repeat(" ", columns))
+    }
+    for c := 0; c < columns; c++ {
+        if stacked {
+            // A cell belongs to the first series reaching it
+            for r := range grid {
+                for s := range series {
+                    if points[s][c] > 0 && row(points[s][c]) <= r {
+                        grid[r][c] = symbols[s%len(symbols)]
+                        break
+                    }
+                }
+            }
+            continue
+        }
+        for s := range series {
+            grid[row(points[s][c])][c] = symbols[s%len(symbols)]
+        }
+    }
+
+    axisWidth := len(strconv.Itoa(top))
+    var lines []string
+    for r, cells := range grid {
+        switch r {
+        case 0:
+            lines = append(lines, fmt.Sprintf("%*d ┤%s", axisWidth, top, string(cells)))
+        case height - 1:
+            lines = append(lines, fmt.Sprintf("%*d ┤%s", axisWidth, 0, string(cells)))
+        default:
+            lines = append(lines, fmt.Sprintf("%*s │%s", axisWidth, "", string(cells)))
+        }
+    }
+    lines = append(lines, strings.Repeat(" ", axisWidth)+" └"+strings.Repeat("─", columns))
+
+    first, last := labels[0], labels[len(labels)-1]
+    gap := max(columns-len(first)-len(last), 1)
+    if len(labels) == 1 {
+        last, gap = "", 0
+    }
+    lines = append(lines, strings.Repeat(" ", axisWidth+2)+first+strings.Repeat(" ", gap)+last)
+
+    if len(series) > 1 {
+        legend := make([]string, len(series))
+        for s := range series {
+            legend[s] = string(symbols[s%len(symbols)]) + " " + series[s].Name
+        }
+        lines = append(lines, strings.Repeat(" ", axisWidth+2)+strings.Join(legend, "  "))
+    }
+    return lines
+}
+
+func (p ConsolePrinter) printLineChart(c report.Report, stacked bool) {
+    symbols := lineMarkers
+    if stacked {
+        symbols = areaFills
+    }
+
+    pterm.DefaultHeader.WithBackgroundStyle(pterm.NewStyle(pterm.BgGreen)).Println(c.GetTitle())
+    for _, line := range linePlot(c.GetLabels(), c.ChartSeries(), stacked, linePlotWidth, linePlotHeight) {
+        pterm.DefaultBasicText.Println(colorSymbols(line, symbols))
+    }
+}
+
+// colorSymbols colors the symbols of the series in a line of a chart.
+func colorSymbols(line string, symbols []rune) string {
+    for i, symbol := range symbols {
+        line = strings.ReplaceAll(line, string(symbol), pterm.NewStyle(seriesColors[i]).Sprint(string(symbol)))
+    }
+    return line
+}
+
+// piePlot draws the slices as a bar split in proportion to their values, followed by a legend
+// with the share and the value of every slice.
+func piePlot(slices []pieSlice, width int) []string {
+    total, labelWidth := 0, 0
+    for _, slice := range slices {
+        total += slice.Value
+        labelWidth = max(labelWidth, len(slice.Label))
+    }
+    if total == 0 {
+        return nil
+    }
+
+    var bar strings.Builder
+    cumulative, end := 0, 0
+    for i, slice := range slices {
+        cumulative += slice.Value
+        next := (cumulative*width + total/2) / total
+        bar.WriteString(strings.Repeat(string(areaFills[i]), next-end))
+        end = next
+    }
+    lines := []string{bar.String()}
+    for i, slice := range slices {
+        lines = append(lines, fmt.Sprintf("%c %-*s %5.1f%%  %d", areaFills[i], labelWidth, slice.Label, float64(slice.Value)*100/float64(total), slice.Value))
+    }
+    return lines
+}
+
+func (p ConsolePrinter) printPieChart(c report.Report) {
+    pterm.DefaultHeader.WithBackgroundStyle(pterm.NewStyle(pterm.BgGreen)).Println(c.GetTitle())
+    for _, line := range piePlot(pieSlices(c), linePlotWidth) {
+        pterm.DefaultBasicText.Println(colorSymbols(line, areaFills))
+    }
+}
+
 func (p ConsolePrinter) printTable(r report.Report) {
-    tableData := pterm.TableData{}
-    tableData = append(tableData, []string{"", ""})
+    columns, rows := r.Table()
+    header := []string{""}
+    for _, column := range columns {
+        header = append(header, column.Name)
+    }
+    tableData := pterm.TableData{header}
     labels := r.GetLabels()
-    for index, data := range r.GetData() {
-        label := labels[index]
-        var value string
-        switch data.IsInt {
-        case true:
-            value = strconv.Itoa(data.IntValue)
-        case false:
-            value = data.StringValue
+    for index, row := range rows {
+        line := []string{labels[index]}
+        for i, value := range row {
+            line = append(line, value.Format(columns[i].Unit))
         }
-        tableData = append(tableData, []string{label, value})
+        tableData = append(tableData, line)
     }
     pterm.DefaultHeader.WithBackgroundStyle(pterm.NewStyle(pterm.BgGreen)).Println(r.GetTitle())
     pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(tableData).Render()
@@ -257,6 +412,15 @@ func (p *ConsolePrinter) Print(s *os.File) {
 			p.printDateHeatMapChart(p.reports[k], s)
         case "table":
             p.printTable(p.reports[k])
+        case "line_chart":
+            p.printLineChart(p.reports[k], false)
+        case "stacked_area":
+            p.printLineChart(p.reports[k], true)
+        case "pie_chart":
+            p.printPieChart(p.reports[k])
+		}
+		if note := p.reports[k].GetNote(); note != "" {
+			pterm.DefaultBasicText.Println(pterm.Yellow(note))
 		}
 	}
     defer s.Close()
@@ -5,8 +5,11 @@ import (
 	"errors"
 
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/k1-end/git-reports/src/report"
 	"github.com/stretchr/testify/assert"
@@ -279,3 +282,125 @@ func TestConsolePrinter_Print(t *testing.T) {
 }
 
 
+
+func TestConsolePrinter_PrintNote(t *testing.T) {
+	// Test for the note of an incomplete report
+	testReport := report.Report{}
+	testReport.SetTitle("Test Table")
+	testReport.SetReportType("table")
+	testReport.SetLabels([]string{"Name"})
+	testReport.SetData([]report.Data{{StringValue: "Alice", IsInt: false}})
+	testReport.SetNote("Incomplete: the analysis timed out")
+
+	r, w, _ := os.Pipe()
+
+	printer := ConsolePrinter{}
+	printer.RegisterReport(testReport)
+	printer.Print(w)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	assert.Contains(t, buf.String(), "Incomplete: the analysis timed out", "Output should contain the note")
+}
+
+func TestLinePlot(t *testing.T) {
+	lines := linePlot([]string{"2024-01", "2024-02", "2024-03", "2024-04"}, []report.Series{{Name: "Commits", Values: []int{0, 2, 4, 2}}}, false, 40, 3)
+	assert.Equal(t, []string{
+		"4 ┤  • ",
+		"  │ • •",
+		"0 ┤•   ",
+		"  └────",
+		"   2024-01 2024-04",
+	}, lines)
+
+	// More values than columns are averaged
+	lines = linePlot([]string{"a", "b", "c", "d"}, []report.Series{{Name: "Commits", Values: []int{2, 4, 0, 0}}}, false, 2, 2)
+	assert.Equal(t, []string{
+		"3 ┤• ",
+		"0 ┤ •",
+		"  └──",
+		"   a d",
+	}, lines)
+
+	assert.Empty(t, linePlot(nil, nil, false, 40, 3))
+}
+
+func TestLinePlot_Series(t *testing.T) {
+	labels := []string{"Q1", "Q2", "Q3"}
+	series := []report.Series{
+		{Name: "Alice", Values: []int{2, 0, 1}},
+		{Name: "Bob", Values: []int{0, 2, 1}},
+	}
+
+	lines := linePlot(labels, series, false, 40, 3)
+	assert.Equal(t, []string{
+		"2 ┤•× ",
+		"  │  ×",
+		"0 ┤×• ",
+		"  └───",
+		"   Q1 Q3",
+		"   • Alice  × Bob",
+	}, lines, "The later series is drawn over the earlier ones")
+
+	lines = linePlot(labels, series, true, 40, 3)
+	assert.Equal(t, []string{
+		"2 ┤█▓▓",
+		"  │█▓█",
+		"0 ┤█▓█",
+		"  └───",
+		"   Q1 Q3",
+		"   █ Alice  ▓ Bob",
+	}, lines, "Stacked series are piled up")
+}
+
+func TestConsolePrinter_printTable_Columns(t *testing.T) {
+	testReport := report.Report{}
+	testReport.SetTitle("Developer activity")
+	testReport.SetReportType("table")
+	testReport.SetLabels([]string{"Alice", "Bob"})
+	testReport.SetColumns([]report.Column{
+		{Name: "Commits", Type: report.TypeInt},
+		{Name: "Size", Type: report.TypeFloat, Unit: "KB"},
+		{Name: "Last commit", Type: report.TypeDate},
+	})
+	testReport.SetRows([][]report.Value{
+		{report.IntValue(12), report.FloatValue(3.5), report.DateValue(time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC))},
+		{report.IntValue(7), report.FloatValue(0.25), report.DateValue(time.Date(2023, time.May, 2, 0, 0, 0, 0, time.UTC))},
+	})
+
+	r, w, _ := os.Pipe()
+	printer := ConsolePrinter{}
+	printer.RegisterReport(testReport)
+	printer.Print(w)
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	lines := strings.Split(regexp.MustCompile(`\x1b\[[0-9;]*m`).ReplaceAllString(buf.String(), ""), "\n")
+
+	var header, alice int
+	for i, line := range lines {
+		if strings.Contains(line, "Commits") {
+			header = i
+		}
+		if strings.Contains(line, "Alice") {
+			alice = i
+		}
+	}
+	assert.Regexp(t, `Commits.*Size.*Last commit`, lines[header], "The columns should be side by side in the header")
+	assert.Regexp(t, `Alice.*12.*3\.5 KB.*2024-01-31`, lines[alice], "The values should be side by side in the row")
+	assert.Less(t, header, alice, "The header should come first")
+}
+
+func TestPiePlot(t *testing.T) {
+	lines := piePlot([]pieSlice{{Label: "Go", Value: 60}, {Label: "Markdown", Value: 30}, {Label: "YAML", Value: 10}}, 20)
+	assert.Equal(t, []string{
+		"████████████▓▓▓▓▓▓▒▒",
+		"█ Go        60.0%  60",
+		"▓ Markdown  30.0%  30",
+		"▒ YAML      10.0%  10",
+	}, lines)
+
+	assert.Equal(t, 20, utf8.RuneCountInString(piePlot([]pieSlice{{Label: "a", Value: 1}, {Label: "b", Value: 1}, {Label: "c", Value: 1}}, 20)[0]), "The bar should fill the width")
+	assert.Empty(t, piePlot(nil, 20), "Nothing should be drawn without slices")
+}
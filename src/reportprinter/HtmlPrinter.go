@@ -19,26 +19,58 @@ type HtmlPrinter struct {
 	BasePrinter
 }
 
+type tableCell struct {
+    Text string
+    Sort string // data-sort attribute, the rows are sorted by number when every cell of the column is one
+}
+
+// sortKey returns the value the HTML tables are sorted by, a number for the numbers, durations and dates.
+// The text of the cells of the other columns than text ones is a number too, so that the column is still
+// sorted by number: the "new" changes above the others, the other texts below the numbers.
+func sortKey(v report.Value, column report.Column) string {
+    switch v.Type {
+    case report.TypeInt:
+        return strconv.Itoa(v.Int)
+    case report.TypeFloat, report.TypePercentage, report.TypeChange:
+        return strconv.FormatFloat(v.Float, 'f', -1, 64)
+    case report.TypeDuration:
+        return strconv.FormatInt(int64(v.Duration.Seconds()), 10)
+    case report.TypeDate:
+        return strconv.FormatInt(v.Date.Unix(), 10)
+    default:
+        if column.Type == report.TypeString {
+            return v.String
+        }
+        if v.String == "new" {
+            return "Infinity" // report.ChangeValue of a label missing from the previous period
+        }
+        return "-Infinity"
+    }
+}
+
 func (p HtmlPrinter) renderTable(r report.Report, elementId int) string {
 	var anon struct {
-        Rows map[string]string
+        Headers []string
+        Rows [][]tableCell
         ElementId int
         Title string
 	}
     anon.ElementId = elementId
-    anon.Rows = make(map[string]string)
     anon.Title = r.GetTitle()
+    columns, rows := r.Table()
     labels := r.GetLabels()
-    for index, data := range r.GetData() {
-        label := labels[index]
-        var value string
-        switch data.IsInt {
-        case true:
-            value = strconv.Itoa(data.IntValue)
-        case false:
-            value = data.StringValue
+    if columns[0].Name != "" {
+        anon.Headers = append(anon.Headers, "")
+        for _, column := range columns {
+            anon.Headers = append(anon.Headers, column.Name)
         }
-        anon.Rows[label] = value
+    }
+    for index, row := range rows {
+        cells := []tableCell{{Text: labels[index], Sort: labels[index]}}
+        for i, value := range row {
+            cells = append(cells, tableCell{Text: value.Format(columns[i].Unit), Sort: sortKey(value, columns[i])})
+        }
+        anon.Rows = append(anon.Rows, cells)
     }
 
 	tmpl, err := template.New("table.html").ParseFS(templatesFS, "templates/table.html")
@@ -166,6 +198,75 @@ func (p HtmlPrinter) renderBartChart(c report.Report, elmentId int) string {
 	return buf.String()
 }
 
+// chartColors are the colors of the series of the line charts and of the slices of the pie charts,
+// the first one is the color of the bar charts.
+var chartColors = []string{"rgb(55, 164, 70)", "rgb(240, 173, 78)", "rgb(23, 162, 184)", "rgb(155, 89, 182)", "rgb(217, 83, 79)", "rgb(52, 101, 164)"}
+
+func (p HtmlPrinter) renderLineChart(c report.Report, elementId int, stacked bool) string {
+	tmpl, err := template.New("line-chart.html").ParseFS(templatesFS, "templates/line-chart.html")
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+	type dataset struct {
+		Label string
+		Data  []int
+		Color string
+	}
+	var anon struct {
+		Title     string
+		Labels    []string
+		Datasets  []dataset
+		Stacked   bool
+		Legend    bool
+		ElementId int
+	}
+	anon.Title = c.GetTitle()
+	anon.Labels = c.GetLabels()
+	for i, series := range c.ChartSeries() {
+		anon.Datasets = append(anon.Datasets, dataset{Label: series.Name, Data: series.Values, Color: chartColors[i%len(chartColors)]})
+	}
+	anon.Stacked = stacked
+	anon.Legend = len(anon.Datasets) > 1
+	anon.ElementId = elementId
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, anon)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+	return buf.String()
+}
+
+func (p HtmlPrinter) renderPieChart(c report.Report, elementId int) string {
+	tmpl, err := template.New("pie-chart.html").ParseFS(templatesFS, "templates/pie-chart.html")
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+	var anon struct {
+		Title     string
+		Labels    []string
+		Data      []int
+		Colors    []string
+		ElementId int
+	}
+	anon.Title = c.GetTitle()
+	for i, slice := range pieSlices(c) {
+		anon.Labels = append(anon.Labels, slice.Label)
+		anon.Data = append(anon.Data, slice.Value)
+		anon.Colors = append(anon.Colors, chartColors[i%len(chartColors)])
+	}
+	anon.ElementId = elementId
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, anon)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+	return buf.String()
+}
+
 func (p HtmlPrinter) Print(s *os.File) {
 	tmpl, err := template.New("main.html").ParseFS(templatesFS, "templates/main.html")
 
@@ -185,6 +286,9 @@ func (p HtmlPrinter) Print(s *os.File) {
 
 	var renderedReports bytes.Buffer
 	for k := range p.reports {
+		if note := p.reports[k].GetNote(); note != "" {
+			renderedReports.WriteString(`<div style="width: 800px;" class="alert alert-warning">` + template.HTMLEscapeString(note) + "</div>\n")
+		}
 		switch p.reports[k].GetReportType() {
 		case "date_heatmap":
 			renderedReports.WriteString(p.renderDateHeatMapChart(p.reports[k], k))
@@ -192,6 +296,12 @@ func (p HtmlPrinter) Print(s *os.File) {
 			renderedReports.WriteString(p.renderBartChart(p.reports[k], k))
 		case "table":
 			renderedReports.WriteString(p.renderTable(p.reports[k], k))
+		case "line_chart":
+			renderedReports.WriteString(p.renderLineChart(p.reports[k], k, false))
+		case "stacked_area":
+			renderedReports.WriteString(p.renderLineChart(p.reports[k], k, true))
+		case "pie_chart":
+			renderedReports.WriteString(p.renderPieChart(p.reports[k], k))
 		}
 		renderedReports.WriteString("\n")
         anon.Reports = append(anon.Reports, struct {
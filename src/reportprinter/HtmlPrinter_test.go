@@ -8,6 +8,7 @@ import (
 
 	"github.com/k1-end/git-reports/src/report"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHtmlPrinter_renderTable(t *testing.T) {
@@ -101,6 +102,49 @@ func TestHtmlPrinter_renderBartChart(t *testing.T) {
 	assert.Contains(t, result, `5`, "Output should contain value 5")
 }
 
+func TestHtmlPrinter_renderLineChart(t *testing.T) {
+	testReport := report.Report{}
+	testReport.SetTitle("Line Chart Example")
+	testReport.SetReportType("line_chart")
+	testReport.SetLabels([]string{"2024-01", "2024-02"})
+	testReport.SetData([]report.Data{
+		{IntValue: 12, IsInt: true},
+		{IntValue: 34, IsInt: true},
+	})
+
+	printer := HtmlPrinter{}
+	result := printer.renderLineChart(testReport, 4, false)
+
+	assert.Regexp(t, regexp.MustCompile(`<canvas id="elem-4"`), result, "Output should contain the canvas with the correct ID")
+	assert.Contains(t, result, "type: 'line'", "Output should be a line chart")
+	assert.Contains(t, result, "Line Chart Example", "Output should contain the title")
+	assert.Contains(t, result, `"2024-01"`, "Output should contain the first label")
+	assert.Contains(t, result, `34`, "Output should contain value 34")
+	assert.Regexp(t, regexp.MustCompile(`fill:\s*false`), result, "Output should not fill the line")
+	assert.Regexp(t, regexp.MustCompile(`legend: \{\s*display:\s*false`), result, "Output should not show the legend of a single series")
+}
+
+func TestHtmlPrinter_renderStackedArea(t *testing.T) {
+	testReport := report.Report{}
+	testReport.SetTitle("Commits per developer")
+	testReport.SetReportType("stacked_area")
+	testReport.SetLabels([]string{"2024-01", "2024-02"})
+	testReport.SetSeries([]report.Series{
+		{Name: "Alice", Values: []int{3, 4}},
+		{Name: "Bob <bob@example.com>", Values: []int{5, 6}},
+	})
+
+	printer := HtmlPrinter{}
+	result := printer.renderLineChart(testReport, 5, true)
+
+	assert.Contains(t, result, `"Alice"`, "Output should contain the first series")
+	assert.Contains(t, result, `"Bob \u003cbob@example.com\u003e"`, "Output should escape the series names")
+	assert.Regexp(t, regexp.MustCompile(`fill:\s*true`), result, "Output should fill the areas")
+	assert.Regexp(t, regexp.MustCompile(`stacked:\s*true`), result, "Output should stack the areas")
+	assert.Contains(t, result, "rgb(240, 173, 78)", "Output should give the second series its own color")
+	assert.Regexp(t, regexp.MustCompile(`legend: \{\s*display:\s*true`), result, "Output should show the legend")
+}
+
 func TestHtmlPrinter_Print(t *testing.T) {
 	// Test for HtmlPrinter.Print
 	printer := HtmlPrinter{}
@@ -177,3 +221,95 @@ func TestHtmlPrinter_Print(t *testing.T) {
 	assert.Contains(t, output, `30`, "Output should contain bar chart data")
 }
 
+
+func TestHtmlPrinter_PrintNote(t *testing.T) {
+	// Test for the note of an incomplete report
+	printer := HtmlPrinter{}
+	tableReport := report.Report{}
+	tableReport.SetTitle("Example Table")
+	tableReport.SetReportType("table")
+	tableReport.SetLabels([]string{"Header 1"})
+	tableReport.SetData([]report.Data{{StringValue: "Data 1", IsInt: false}})
+	tableReport.SetNote("Incomplete: <interrupted>")
+	printer.RegisterReport(tableReport)
+
+	tmpFile, err := os.CreateTemp("", "test_output.html")
+	if err != nil {
+		t.Fatalf("Failed to create temporary file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	printer.Print(tmpFile)
+	tmpFile.Close()
+
+	content, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Failed to read temporary file: %v", err)
+	}
+	assert.Contains(t, string(content), `class="alert alert-warning">Incomplete: &lt;interrupted&gt;</div>`, "Output should contain the escaped note")
+}
+
+func TestHtmlPrinter_renderTable_Columns(t *testing.T) {
+	testReport := report.Report{}
+	testReport.SetTitle("Developer activity")
+	testReport.SetReportType("table")
+	testReport.SetLabels([]string{"Zoe", "Alice", "Mike"})
+	testReport.SetColumns([]report.Column{
+		{Name: "Commits", Type: report.TypeInt},
+		{Name: "Share", Type: report.TypePercentage},
+		{Name: "Last commit", Type: report.TypeDate},
+	})
+	last := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	testReport.SetRows([][]report.Value{
+		{report.IntValue(12), report.PercentageValue(60), report.DateValue(last)},
+		{report.IntValue(5), report.PercentageValue(25), report.DateValue(last)},
+		{report.IntValue(3), report.PercentageValue(15), report.DateValue(last)},
+	})
+
+	printer := HtmlPrinter{}
+	result := printer.renderTable(testReport, 1)
+
+	assert.Regexp(t, `(?s)<thead>.*Commits.*Share.*Last commit.*</thead>`, result, "The columns should be in the header")
+	assert.Regexp(t, `onclick="sortTable\('elem-1',\s*1\s*\)">Commits`, result, "The headers should sort the table")
+	assert.Contains(t, result, `oninput="filterTable(this, 'elem-1')"`, "The table should have a filter")
+	assert.Regexp(t, `(?s)Zoe.*Alice.*Mike`, result, "The rows should keep their order")
+	assert.Contains(t, result, `<td data-sort="60">60%</td>`, "The percentages should be sorted by number")
+	assert.Contains(t, result, `<td data-sort="1706659200">2024-01-31</td>`, "The dates should be sorted by timestamp")
+}
+
+func TestHtmlPrinter_renderTable_MixedChanges(t *testing.T) {
+	previous := report.Report{}
+	previous.SetReportType("bar_chart")
+	previous.SetLabels([]string{"Alice", "Bob"})
+	previous.SetData([]report.Data{{IsInt: true, IntValue: 10}, {IsInt: true, IntValue: 4}})
+	current := report.Report{}
+	current.SetReportType("bar_chart")
+	current.SetLabels([]string{"Alice", "Bob", "Carol"})
+	current.SetData([]report.Data{{IsInt: true, IntValue: 15}, {IsInt: true, IntValue: 2}, {IsInt: true, IntValue: 3}})
+	compared, ok := report.Compare(previous, current, "Before", "After")
+	require.True(t, ok)
+
+	printer := HtmlPrinter{}
+	result := printer.renderTable(compared, 1)
+
+	assert.Contains(t, result, `<td data-sort="50">&#43;50%</td>`, "The changes should be sorted by number")
+	assert.Contains(t, result, `<td data-sort="-50">-50%</td>`)
+	assert.Contains(t, result, `<td data-sort="Infinity">new</td>`, "The new labels should be sorted above the changes, not as text")
+}
+
+func TestHtmlPrinter_renderPieChart(t *testing.T) {
+	testReport := report.Report{}
+	testReport.SetTitle("Languages (KB)")
+	testReport.SetReportType("pie_chart")
+	testReport.SetLabels([]string{"Markdown", "Go"})
+	testReport.SetData([]report.Data{{IntValue: 30, IsInt: true}, {IntValue: 60, IsInt: true}})
+
+	printer := HtmlPrinter{}
+	result := printer.renderPieChart(testReport, 2)
+
+	assert.Contains(t, result, `id="elem-2"`, "Output should contain the canvas with the correct ID")
+	assert.Contains(t, result, "type: 'doughnut'", "Output should draw a doughnut chart")
+	assert.Contains(t, result, "Languages (KB)", "Output should contain the title")
+	assert.Regexp(t, `(?s)"Go".*"Markdown"`, result, "The biggest slice should come first")
+	assert.Regexp(t, `(?s)60\s*,\s*30\s*,`, result, "Output should contain the values")
+	assert.Contains(t, result, "rgb(55, 164, 70)", "Output should contain the colors of the slices")
+}
@@ -2,6 +2,7 @@ package reportprinter
 
 import (
 	"os"
+	"sort"
 
 	"github.com/k1-end/git-reports/src/report"
 )
@@ -32,3 +33,34 @@ func (p *BasePrinter) GetProjectTitle() string {
 func (p *BasePrinter) GetReports() []report.Report {
 	return p.reports
 }
+
+// maxPieSlices limits the slices of the pie charts, the smallest ones are summed up in "Others".
+const maxPieSlices = 6
+
+type pieSlice struct {
+	Label string
+	Value int
+}
+
+// pieSlices returns the positive values of a pie chart report from the biggest to the smallest,
+// keeping at most maxPieSlices slices.
+func pieSlices(r report.Report) []pieSlice {
+	labels := r.GetLabels()
+	var slices []pieSlice
+	for i, data := range r.GetData() {
+		if data.IntValue > 0 {
+			slices = append(slices, pieSlice{Label: labels[i], Value: data.IntValue})
+		}
+	}
+	sort.SliceStable(slices, func(i, j int) bool {
+		return slices[i].Value > slices[j].Value
+	})
+	if len(slices) > maxPieSlices {
+		others := pieSlice{Label: "Others"}
+		for _, slice := range slices[maxPieSlices-1:] {
+			others.Value += slice.Value
+		}
+		slices = append(slices[:maxPieSlices-1], others)
+	}
+	return slices
+}
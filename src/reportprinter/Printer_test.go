@@ -87,3 +87,31 @@ func TestBasePrinter_GetReports(t *testing.T) {
 	reports = printer.GetReports()
 	assert.Empty(t, reports, "GetReports should return an empty slice when there are no reports")
 }
+
+func TestPieSlices(t *testing.T) {
+	testReport := report.Report{}
+	testReport.SetLabels([]string{"a", "b", "c", "d", "e", "f", "g", "h"})
+	testReport.SetData([]report.Data{
+		{IntValue: 5, IsInt: true},
+		{IntValue: 40, IsInt: true},
+		{IntValue: 0, IsInt: true},
+		{IntValue: 20, IsInt: true},
+		{IntValue: 10, IsInt: true},
+		{IntValue: 8, IsInt: true},
+		{IntValue: 3, IsInt: true},
+		{IntValue: 30, IsInt: true},
+	})
+
+	assert.Equal(t, []pieSlice{
+		{Label: "b", Value: 40},
+		{Label: "h", Value: 30},
+		{Label: "d", Value: 20},
+		{Label: "e", Value: 10},
+		{Label: "f", Value: 8},
+		{Label: "Others", Value: 8},
+	}, pieSlices(testReport), "The slices should be sorted, without the empty ones and with the smallest ones summed up")
+
+	testReport.SetLabels([]string{"a", "b"})
+	testReport.SetData([]report.Data{{IntValue: 1, IsInt: true}, {IntValue: 3, IsInt: true}})
+	assert.Equal(t, []pieSlice{{Label: "b", Value: 3}, {Label: "a", Value: 1}}, pieSlices(testReport))
+}